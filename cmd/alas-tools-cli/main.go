@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/cli"
 	"github.com/Cait-dev/alas-tools-cli/internal/config"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/logging"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
 	"github.com/Cait-dev/alas-tools-cli/internal/ui"
 )
 
@@ -13,16 +19,70 @@ var (
 )
 
 func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "-v" || os.Args[1] == "--version") {
-		fmt.Printf("Alas-Tools-Cli versión %s\n", version)
-		return
-	}
+	os.Exit(run())
+}
 
+func run() int {
 	config.LoadEnv()
 
+	i18n.Init(config.Language())
+
+	opts, args, err := cli.ParseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("main.error", err))
+		return apperr.ExitCode(err)
+	}
+
+	if opts.Version {
+		fmt.Println(i18n.T("main.version", version))
+		return apperr.ExitOK
+	}
+
+	if opts.Help {
+		args = []string{"help"}
+	}
+
+	output.Init(opts.NoColor, opts.Plain)
+
+	if err := logging.Setup(opts.LogLevel, opts.LogFormat); err != nil {
+		if apperr.KindOf(err) == apperr.KindUsage {
+			fmt.Fprintln(os.Stderr, i18n.T("main.error", err))
+			return apperr.ExitCode(err)
+		}
+		output.Warning(i18n.T("log.setup_error", err))
+		logging.Discard()
+	}
+	defer logging.Close()
+
+	slog.Info("inicio", "version", version, "args", args, "lang", i18n.Lang())
+
+	if len(args) > 0 {
+		err := cli.Run(args)
+		if apperr.IsPartial(err) {
+			output.Warning(err.Error())
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("main.error", err))
+		}
+
+		code := apperr.ExitCode(err)
+		if err != nil {
+			slog.Error("comando terminado con error", "command", args[0], "exit_code", code, "error", err)
+		} else {
+			slog.Info("comando terminado", "command", args[0])
+		}
+		return code
+	}
+
+	if !output.IsInteractive() {
+		fmt.Fprintln(os.Stderr, i18n.T("main.needs_terminal"))
+		cli.Run([]string{"help"})
+		return apperr.ExitUsage
+	}
+
 	ui.ShowStartScreen()
 
 	ui.StartMainMenu()
 
-	fmt.Println("\n¡Hasta pronto! Gracias por usar Alas-Tools-Cli.")
+	fmt.Println("\n" + i18n.T("main.goodbye"))
+	return apperr.ExitOK
 }
@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
 )
 
 type Client struct {
@@ -37,31 +42,46 @@ func (c *Client) SearchDeliveryOrders(palletCodes []string, pageNumber, pageSize
 
 	requestJSON, err := json.Marshal(requestBody)
 	if err != nil {
-		return nil, fmt.Errorf("error al crear la petición: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("api.err_request"), err)
 	}
 
 	client := &http.Client{}
 	req, err := http.NewRequest("POST", c.BaseURL+"/delivery/delivery-orders/cl/_search", bytes.NewBuffer(requestJSON))
 	if err != nil {
-		return nil, fmt.Errorf("error al crear la petición: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("api.err_request"), err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.SetBasicAuth(c.Username, c.Password)
 
+	slog.Debug("consultando órdenes de entrega", "pallets", palletCodes, "page_number", pageNumber, "page_size", pageSize, "source_fields", sourceFields)
+
+	inicio := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error al conectar con la API: %w", err)
+		slog.Error("error al conectar con la API", "url", req.URL.String(), "error", err)
+		return nil, apperr.WithMessage(apperr.KindNetwork, i18n.T("api.err_connect"), err)
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error al leer la respuesta: %w", err)
+		return nil, apperr.WithMessage(apperr.KindNetwork, i18n.T("api.err_response"), err)
+	}
+
+	slog.Info("respuesta de la API", "status", resp.StatusCode, "bytes", len(body), "duration", time.Since(inicio))
+
+	if resp.StatusCode != http.StatusOK {
+		slog.Warn("la API respondió con error", "status", resp.StatusCode, "body", string(body))
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("código de estado: %d - %s", resp.StatusCode, string(body))
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, apperr.New(apperr.KindAuth, i18n.T("api.err_auth", resp.StatusCode))
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, apperr.New(apperr.KindNotFound, i18n.T("api.err_status", resp.StatusCode, string(body)))
+	case resp.StatusCode != http.StatusOK:
+		return nil, apperr.New(apperr.KindNetwork, i18n.T("api.err_status", resp.StatusCode, string(body)))
 	}
 
 	return body, nil
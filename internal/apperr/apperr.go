@@ -0,0 +1,94 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Códigos de salida documentados del proceso.
+const (
+	ExitOK       = 0
+	ExitGeneric  = 1
+	ExitUsage    = 2
+	ExitAuth     = 3
+	ExitNotFound = 4
+	ExitNetwork  = 5
+	ExitPartial  = 6
+	ExitIO       = 7
+)
+
+type Kind int
+
+const (
+	KindGeneric Kind = iota
+	KindUsage
+	KindAuth
+	KindNotFound
+	KindNetwork
+	KindPartial
+	KindIO
+)
+
+var exitCodes = map[Kind]int{
+	KindGeneric:  ExitGeneric,
+	KindUsage:    ExitUsage,
+	KindAuth:     ExitAuth,
+	KindNotFound: ExitNotFound,
+	KindNetwork:  ExitNetwork,
+	KindPartial:  ExitPartial,
+	KindIO:       ExitIO,
+}
+
+// Error asocia un error con su categoría para poder traducirlo a un código de
+// salida.
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, message string) error {
+	return &Error{Kind: kind, Err: errors.New(message)}
+}
+
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// WithMessage antepone un mensaje al error original, conservándolo para
+// errors.Is y errors.As.
+func WithMessage(kind Kind, message string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: fmt.Errorf("%s: %w", message, err)}
+}
+
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindGeneric
+}
+
+func IsPartial(err error) bool {
+	return err != nil && KindOf(err) == KindPartial
+}
+
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	return exitCodes[KindOf(err)]
+}
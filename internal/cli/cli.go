@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/config"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/export"
+	"github.com/Cait-dev/alas-tools-cli/internal/handlers"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/importer"
+	"github.com/Cait-dev/alas-tools-cli/internal/logging"
+	"github.com/Cait-dev/alas-tools-cli/internal/mapassets"
+	"github.com/Cait-dev/alas-tools-cli/internal/outfile"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+)
+
+const programName = "alas-cli"
+
+// Tipos de sugerencia dinámica que ofrecen los scripts de autocompletado
+// para los argumentos de un comando.
+const (
+	completeNone    = ""
+	completePallets = "pallets"
+	completeFiles   = "files"
+	// completeProfiles solo se usa para el valor de --profile.
+	completeProfiles = "profiles"
+)
+
+// Command describe un subcomando. Usage y Description son claves del catálogo
+// de mensajes para que la ayuda se muestre en el idioma configurado.
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	Complete    string
+	Hidden      bool
+	Run         func(args []string) error
+	// Subcommands se eligen con el primer argumento, como "coords diff".
+	Subcommands []*Command
+}
+
+var commands []*Command
+
+func init() {
+	commands = []*Command{
+		{
+			Name:        "coords",
+			Usage:       "cli.usage.coords",
+			Description: "cli.cmd.coords",
+			Complete:    completePallets,
+			Run:         runCoords,
+			Subcommands: []*Command{
+				{
+					Name:        "diff",
+					Usage:       "cli.usage.coords_diff",
+					Description: "cli.cmd.coords_diff",
+					Complete:    completeFiles,
+					Run:         runCoordsDiff,
+				},
+				{
+					Name:        "watch",
+					Usage:       "cli.usage.coords_watch",
+					Description: "cli.cmd.coords_watch",
+					Complete:    completePallets,
+					Run:         runCoordsWatch,
+				},
+			},
+		},
+		{
+			Name:        "map",
+			Usage:       "cli.usage.map",
+			Description: "cli.cmd.map",
+			Complete:    completeFiles,
+			Run:         runMap,
+			Subcommands: []*Command{
+				{
+					Name:        "compare",
+					Usage:       "cli.usage.map_compare",
+					Description: "cli.cmd.map_compare",
+					Complete:    completeFiles,
+					Run:         runMapCompare,
+				},
+			},
+		},
+		{
+			Name:        "completion",
+			Usage:       "cli.usage.completion",
+			Description: "cli.cmd.completion",
+			Run:         runCompletion,
+		},
+		{
+			Name:        "help",
+			Usage:       "cli.usage.help",
+			Description: "cli.cmd.help",
+			Run:         runHelp,
+		},
+		{
+			Name:   completeCommandName,
+			Hidden: true,
+			Run:    runComplete,
+		},
+	}
+}
+
+func lookup(name string) *Command {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func visibleCommands() []*Command {
+	var visibles []*Command
+	for _, cmd := range commands {
+		if !cmd.Hidden {
+			visibles = append(visibles, cmd)
+		}
+	}
+	return visibles
+}
+
+// Run ejecuta el subcomando indicado en args[0] de forma no interactiva.
+func Run(args []string) error {
+	cmd := lookup(args[0])
+	if cmd == nil {
+		return apperr.New(apperr.KindUsage, i18n.T("cli.unknown_command", args[0], programName))
+	}
+
+	output.SetInteractive(false)
+	args = args[1:]
+	if len(args) > 0 {
+		for _, sub := range cmd.Subcommands {
+			if sub.Name == args[0] {
+				return sub.Run(args[1:])
+			}
+		}
+	}
+	return cmd.Run(args)
+}
+
+func runCoords(args []string) error {
+	opts, err := handlers.DefaultCoordsOptions()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("coords", flag.ContinueOnError)
+	applyFlags := bindCoordsFlags(fs, &opts)
+	fs.BoolVar(&opts.Map, "map", false, "")
+	fs.BoolVar(&opts.JSON, "json", false, "")
+
+	pallets, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pallets) == 0 {
+		return usageError(lookup("coords"))
+	}
+
+	if err := applyFlags(); err != nil {
+		return err
+	}
+	if err := checkOffline(opts.Map && opts.MapOffline); err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		output.SetJSONMode()
+	}
+
+	return handlers.ObtenerCoordenadas(strings.Join(pallets, ","), opts)
+}
+
+// bindCoordsFlags define las opciones de extracción comunes a coords y coords
+// watch. La función devuelta las valida y las aplica a opts una vez leídos
+// los argumentos.
+func bindCoordsFlags(fs *flag.FlagSet, opts *handlers.CoordsOptions) func() error {
+	exportFormats := fs.String("export", strings.Join(opts.Export.Formats, ","), "")
+	fs.BoolVar(&opts.Export.Route, "route", opts.Export.Route, "")
+	exportColumns := fs.String("columns", strings.Join(opts.Export.Columns, ","), "")
+	region := fs.String("region", "", "")
+	outliers := fs.String("outliers", "", "")
+	consolidate := fs.String("consolidate", "", "")
+	fs.StringVar(&opts.Output.Dir, "output-dir", opts.Output.Dir, "")
+	name := fs.String("name", "", "")
+	fs.BoolVar(&opts.Output.Daily, "daily", opts.Output.Daily, "")
+	overwrite := fs.String("overwrite", "", "")
+	fs.StringVar(&opts.Output.Profile, "profile", opts.Output.Profile, "")
+	fs.BoolVar(&opts.MapOffline, "offline", opts.MapOffline, "")
+
+	return func() error {
+		var err error
+		opts.Export.Formats, err = export.ParseFormats(*exportFormats)
+		if err != nil {
+			return apperr.New(apperr.KindUsage, i18n.T("export.unsupported", err))
+		}
+
+		opts.Export.Columns, err = export.ParseColumns(*exportColumns)
+		if err != nil {
+			return apperr.New(apperr.KindUsage, i18n.T("export.unsupported_column", err))
+		}
+
+		if *region != "" {
+			if opts.Region, err = handlers.LoadRegion(*region); err != nil {
+				return err
+			}
+		}
+
+		if *outliers != "" {
+			if opts.OutlierMethod, err = coords.ParseOutlierMethod(*outliers); err != nil {
+				return apperr.New(apperr.KindUsage, i18n.T("outliers.unsupported", err))
+			}
+		}
+
+		if *consolidate != "" {
+			if opts.ConsolidateRadius, err = handlers.ParseConsolidateRadius(*consolidate); err != nil {
+				return err
+			}
+		}
+
+		if *name != "" {
+			if opts.Output.Template, err = outfile.ParseTemplate(*name); err != nil {
+				return apperr.New(apperr.KindUsage, i18n.T("outfile.invalid_template", err))
+			}
+		}
+
+		if *overwrite != "" {
+			if opts.Output.Policy, err = outfile.ParsePolicy(*overwrite); err != nil {
+				return apperr.New(apperr.KindUsage, i18n.T("outfile.unsupported_policy", err))
+			}
+		}
+		return nil
+	}
+}
+
+func runCoordsWatch(args []string) error {
+	opts, err := handlers.DefaultCoordsOptions()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("coords watch", flag.ContinueOnError)
+	applyFlags := bindCoordsFlags(fs, &opts)
+	palletFlag := fs.String("pallet", "", "")
+	interval := fs.Duration("interval", 2*time.Minute, "")
+
+	pallets, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *palletFlag != "" {
+		pallets = append([]string{*palletFlag}, pallets...)
+	}
+	if len(pallets) == 0 {
+		return usageError(lookup("coords").Subcommands[1])
+	}
+
+	if err := applyFlags(); err != nil {
+		return err
+	}
+	if err := checkOffline(opts.MapOffline); err != nil {
+		return err
+	}
+
+	return handlers.VigilarPallets(strings.Join(pallets, ","), *interval, opts)
+}
+
+func runCoordsDiff(args []string) error {
+	opts := handlers.DiffOptions{Offline: config.MapOffline()}
+	fs := flag.NewFlagSet("coords diff", flag.ContinueOnError)
+	fs.StringVar(&opts.MapFile, "map", "", "")
+	fs.BoolVar(&opts.Offline, "offline", opts.Offline, "")
+	fs.BoolVar(&opts.JSON, "json", false, "")
+
+	files, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 && len(files) != 2 {
+		return usageError(lookup("coords").Subcommands[0])
+	}
+	if err := checkOffline(opts.MapFile != "" && opts.Offline); err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		output.SetJSONMode()
+	}
+
+	despues := ""
+	if len(files) == 2 {
+		despues = files[1]
+	}
+	return handlers.CompararCoordenadas(files[0], despues, opts)
+}
+
+func runMap(args []string) error {
+	opts := handlers.MapOptions{Offline: config.MapOffline()}
+	fs := flag.NewFlagSet("map", flag.ContinueOnError)
+	applyFlags := bindMapFlags(fs, &opts.Input, &opts.Offline)
+
+	files, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return usageError(lookup("map"))
+	}
+
+	if err := applyFlags(); err != nil {
+		return err
+	}
+
+	return handlers.GenerarMapaHTML(files[0], opts)
+}
+
+func runMapCompare(args []string) error {
+	opts := handlers.CompareOptions{Offline: config.MapOffline()}
+	fs := flag.NewFlagSet("map compare", flag.ContinueOnError)
+	applyFlags := bindMapFlags(fs, &opts.Input, &opts.Offline)
+	fs.StringVar(&opts.MapFile, "map", "", "")
+	fs.BoolVar(&opts.JSON, "json", false, "")
+
+	files, err := parseCommandFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) != 2 {
+		return usageError(lookup("map").Subcommands[0])
+	}
+
+	if err := applyFlags(); err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		output.SetJSONMode()
+	}
+
+	return handlers.CompararSecuencias(files[0], files[1], opts)
+}
+
+// bindMapFlags define las opciones de lectura de archivos y de Leaflet
+// comunes a map y map compare. La función devuelta las valida una vez leídos
+// los argumentos.
+func bindMapFlags(fs *flag.FlagSet, input *importer.Options, offline *bool) func() error {
+	format := fs.String("format", importer.FormatAuto, "")
+	columns := fs.String("columns", "", "")
+	fs.BoolVar(offline, "offline", *offline, "")
+
+	return func() error {
+		var err error
+		if input.Format, err = importer.ParseFormat(*format); err != nil {
+			return apperr.New(apperr.KindUsage, i18n.T("import.unsupported_format", err))
+		}
+		if input.Columns, err = importer.ParseColumns(*columns); err != nil {
+			return apperr.New(apperr.KindUsage, i18n.T("import.invalid_columns", err))
+		}
+		return checkOffline(*offline)
+	}
+}
+
+// checkOffline rechaza la opción --offline al leer los argumentos, antes de
+// consultar la API o leer archivos, si el binario se compiló sin Leaflet.
+func checkOffline(offline bool) error {
+	if offline && !mapassets.Available() {
+		return apperr.New(apperr.KindUsage, i18n.T("map.offline_missing"))
+	}
+	return nil
+}
+
+func runHelp(args []string) error {
+	fmt.Println(i18n.T("cli.help.usage", programName))
+	fmt.Println("\n" + i18n.T("cli.help.menu"))
+	fmt.Println("\n" + i18n.T("cli.help.commands"))
+	for _, cmd := range visibleCommands() {
+		fmt.Printf("  %s\n      %s\n", i18n.T(cmd.Usage), i18n.T(cmd.Description))
+		for _, sub := range cmd.Subcommands {
+			fmt.Printf("  %s\n      %s\n", i18n.T(sub.Usage), i18n.T(sub.Description))
+		}
+	}
+	fmt.Println("\n" + i18n.T("cli.help.options"))
+	fmt.Printf("  %-36s %s\n", "-v, --version", i18n.T("cli.opt.version"))
+	fmt.Printf("  %-36s %s\n", "--no-color", i18n.T("cli.opt.no_color"))
+	fmt.Printf("  %-36s %s\n", "--plain", i18n.T("cli.opt.plain"))
+	fmt.Printf("  %-36s %s\n", "--log-level debug|info|warn|error", i18n.T("cli.opt.log_level"))
+	fmt.Printf("  %-36s %s\n", "--log-format text|json", i18n.T("cli.opt.log_format", logging.Path()))
+	return nil
+}
+
+func usageError(cmd *Command) error {
+	return apperr.New(apperr.KindUsage, i18n.T("cli.usage", programName, i18n.T(cmd.Usage)))
+}
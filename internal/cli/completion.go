@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/config"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/importer"
+	"github.com/Cait-dev/alas-tools-cli/internal/outfile"
+)
+
+// completeCommandName es el comando oculto que consultan los scripts de
+// autocompletado para obtener sugerencias dinámicas.
+const completeCommandName = "__complete"
+
+const bashCompletion = `# Autocompletado de bash para {{.Program}}
+# Uso: source <({{.Program}} completion bash)
+
+_{{.Func}}() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=( $(compgen -W "{{.Commands}}" -- "$cur") )
+        return
+    fi
+
+    if [ "${COMP_WORDS[COMP_CWORD-1]}" = "--profile" ]; then
+        COMPREPLY=( $(compgen -W "$({{.Program}} {{.Hidden}} {{.Profiles}} 2>/dev/null)" -- "$cur") )
+        return
+    fi
+
+    case "${COMP_WORDS[1]}" in
+{{- range .Dynamic}}
+        {{.Name}})
+            COMPREPLY=( $(compgen -W "{{subs .}} $({{$.Program}} {{$.Hidden}} {{.Complete}} 2>/dev/null)" -- "$cur") )
+            {{- if eq .Complete "files"}}
+            [ ${#COMPREPLY[@]} -eq 0 ] && COMPREPLY=( $(compgen -f -- "$cur") )
+            {{- end}}
+            ;;
+{{- end}}
+        completion)
+            COMPREPLY=( $(compgen -W "{{.Shells}}" -- "$cur") )
+            ;;
+    esac
+}
+
+complete -F _{{.Func}} {{.Program}} alas-tools-cli
+`
+
+const zshCompletion = `#compdef {{.Program}} alas-tools-cli
+# Autocompletado de zsh para {{.Program}}
+# Uso: source <({{.Program}} completion zsh)
+
+_{{.Func}}() {
+    local -a suggestions
+
+    if (( CURRENT == 2 )); then
+        suggestions=({{range .Visible}}'{{.Name}}:{{t .Description}}' {{end}})
+        _describe '{{t "cli.completion.command"}}' suggestions
+        return
+    fi
+
+    if [[ "${words[CURRENT-1]}" == --profile ]]; then
+        suggestions=(${(f)"$({{.Program}} {{.Hidden}} {{.Profiles}} 2>/dev/null)"})
+        compadd -a suggestions
+        return
+    fi
+
+    case "${words[2]}" in
+{{- range .Dynamic}}
+        {{.Name}})
+            suggestions=({{subs .}} ${(f)"$({{$.Program}} {{$.Hidden}} {{.Complete}} 2>/dev/null)"})
+            {{- if eq .Complete "files"}}
+            (( ${#suggestions} )) && compadd -a suggestions || _files
+            {{- else}}
+            compadd -a suggestions
+            {{- end}}
+            ;;
+{{- end}}
+        completion)
+            compadd {{.Shells}}
+            ;;
+    esac
+}
+
+if [ "$funcstack[1]" = "_{{.Func}}" ]; then
+    _{{.Func}} "$@"
+else
+    compdef _{{.Func}} {{.Program}} alas-tools-cli
+fi
+`
+
+const fishCompletion = `# Autocompletado de fish para {{.Program}}
+# Uso: {{.Program}} completion fish | source
+
+for cmd in {{.Program}} alas-tools-cli
+    complete -c $cmd -f
+{{- range .Visible}}
+    complete -c $cmd -n '__fish_use_subcommand' -a '{{.Name}}' -d '{{t .Description}}'
+{{- end}}
+{{- range .Dynamic}}
+    complete -c $cmd -n '__fish_seen_subcommand_from {{.Name}}' -a '{{subs .}} ({{$.Program}} {{$.Hidden}} {{.Complete}} 2>/dev/null)'
+    {{- if eq .Complete "files"}}
+    complete -c $cmd -n '__fish_seen_subcommand_from {{.Name}}' -F
+    {{- end}}
+{{- end}}
+    complete -c $cmd -n '__fish_seen_subcommand_from coords' -l profile -x -a '({{.Program}} {{.Hidden}} {{.Profiles}} 2>/dev/null)'
+    complete -c $cmd -n '__fish_seen_subcommand_from completion' -a '{{.Shells}}'
+end
+`
+
+var completionScripts = map[string]string{
+	"bash": bashCompletion,
+	"zsh":  zshCompletion,
+	"fish": fishCompletion,
+}
+
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return usageError(lookup("completion"))
+	}
+
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return apperr.New(apperr.KindUsage, i18n.T("cli.unsupported_shell", args[0]))
+	}
+
+	tmpl, err := template.New(args[0]).Funcs(template.FuncMap{
+		"t":    i18n.T,
+		"subs": subcommandNames,
+	}).Parse(script)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("map.err_template"), err)
+	}
+
+	var nombres []string
+	var dinamicos []*Command
+	for _, cmd := range visibleCommands() {
+		nombres = append(nombres, cmd.Name)
+		if cmd.Complete != completeNone {
+			dinamicos = append(dinamicos, cmd)
+		}
+	}
+
+	datos := struct {
+		Program  string
+		Func     string
+		Hidden   string
+		Profiles string
+		Commands string
+		Shells   string
+		Visible  []*Command
+		Dynamic  []*Command
+	}{
+		Program:  programName,
+		Func:     strings.ReplaceAll(programName, "-", "_"),
+		Hidden:   completeCommandName,
+		Profiles: completeProfiles,
+		Commands: strings.Join(nombres, " "),
+		Shells:   "bash zsh fish",
+		Visible:  visibleCommands(),
+		Dynamic:  dinamicos,
+	}
+
+	return tmpl.Execute(os.Stdout, datos)
+}
+
+// subcommandNames devuelve los subcomandos de cmd separados por espacios,
+// para sugerirlos junto con las sugerencias dinámicas.
+func subcommandNames(cmd *Command) string {
+	var nombres []string
+	for _, sub := range cmd.Subcommands {
+		nombres = append(nombres, sub.Name)
+	}
+	return strings.Join(nombres, " ")
+}
+
+// runComplete imprime una sugerencia por línea para el tipo solicitado.
+func runComplete(args []string) error {
+	if len(args) != 1 {
+		return nil
+	}
+
+	var sugerencias []string
+	switch args[0] {
+	case completePallets:
+		sugerencias = config.RecentPallets()
+	case completeFiles:
+		sugerencias = coordinateFiles()
+	case completeProfiles:
+		sugerencias = config.RecentProfiles()
+	}
+
+	for _, s := range sugerencias {
+		fmt.Println(s)
+	}
+	return nil
+}
+
+func coordinateFiles() []string {
+	archivos := outfile.Files(config.OutputDir(), importer.Extensions())
+	sort.Strings(archivos)
+	return archivos
+}
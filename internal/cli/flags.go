@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"flag"
+	"io"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/logging"
+)
+
+// Options son las opciones globales que se aceptan antes del subcomando.
+type Options struct {
+	Version   bool
+	Help      bool
+	NoColor   bool
+	Plain     bool
+	LogLevel  string
+	LogFormat string
+}
+
+func ParseGlobalFlags(args []string) (Options, []string, error) {
+	var opts Options
+
+	fs := flag.NewFlagSet(programName, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&opts.Version, "v", false, "")
+	fs.BoolVar(&opts.Version, "version", false, "")
+	fs.BoolVar(&opts.Help, "h", false, "")
+	fs.BoolVar(&opts.Help, "help", false, "")
+	fs.BoolVar(&opts.NoColor, "no-color", false, "")
+	fs.BoolVar(&opts.Plain, "plain", false, "")
+	fs.StringVar(&opts.LogLevel, "log-level", logging.DefaultLevel, "")
+	fs.StringVar(&opts.LogFormat, "log-format", logging.DefaultFormat, "")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, apperr.Wrap(apperr.KindUsage, err)
+	}
+
+	return opts, fs.Args(), nil
+}
+
+// parseCommandFlags interpreta las opciones de un subcomando permitiendo que
+// aparezcan antes o después de los argumentos posicionales.
+func parseCommandFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, apperr.Wrap(apperr.KindUsage, err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
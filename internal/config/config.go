@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
 )
 
 func LoadEnv() {
@@ -37,7 +39,7 @@ func LoadEnv() {
 	}
 
 	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error al leer el archivo .env: %v\n", err)
+		fmt.Fprintln(os.Stderr, i18n.T("config.env_error", err))
 	}
 }
 
@@ -47,12 +49,95 @@ func GetAPICredentials() (string, string) {
 
 	if apiUser == "" {
 		apiUser = "dev_user"
-		fmt.Println("Advertencia: ALAS_API_USER no está configurada, usando valor predeterminado para desarrollo")
+		fmt.Fprintln(os.Stderr, i18n.T("config.missing_user"))
 	}
 	if apiPassword == "" {
 		apiPassword = "dev_password"
-		fmt.Println("Advertencia: ALAS_API_PASSWORD no está configurada, usando valor predeterminado para desarrollo")
+		fmt.Fprintln(os.Stderr, i18n.T("config.missing_password"))
 	}
 
 	return apiUser, apiPassword
 }
+
+// Language devuelve el idioma configurado en ALAS_LANG (por ejemplo "es" o "en").
+func Language() string {
+	return os.Getenv("ALAS_LANG")
+}
+
+// ExportFormats devuelve los formatos de exportación adicionales configurados
+// en ALAS_EXPORT_FORMATS, separados por comas (por ejemplo "geojson").
+func ExportFormats() string {
+	return os.Getenv("ALAS_EXPORT_FORMATS")
+}
+
+// ExportColumns devuelve las columnas de las exportaciones CSV y XLSX
+// configuradas en ALAS_EXPORT_COLUMNS, separadas por comas.
+func ExportColumns() string {
+	return os.Getenv("ALAS_EXPORT_COLUMNS")
+}
+
+// ExportRoute indica si las exportaciones deben incluir la línea de la ruta.
+func ExportRoute() bool {
+	return parseBool(os.Getenv("ALAS_EXPORT_ROUTE"))
+}
+
+func parseBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "s", "si", "sí", "y", "yes":
+		return true
+	}
+	return false
+}
+
+// Region devuelve la región usada para validar coordenadas, configurada en
+// ALAS_REGION: un nombre predefinido ("chile", "rm") o la ruta a un GeoJSON.
+func Region() string {
+	return os.Getenv("ALAS_REGION")
+}
+
+// OutlierMethod devuelve el método de detección de paradas atípicas
+// configurado en ALAS_OUTLIER_METHOD (mad, iqr, dbscan u off).
+func OutlierMethod() string {
+	return os.Getenv("ALAS_OUTLIER_METHOD")
+}
+
+// ConsolidateRadius devuelve el radio en metros para agrupar órdenes en una
+// misma parada, configurado en ALAS_CONSOLIDATE_RADIUS.
+func ConsolidateRadius() string {
+	return os.Getenv("ALAS_CONSOLIDATE_RADIUS")
+}
+
+// OutputDir devuelve el directorio donde se escriben los archivos generados,
+// configurado en ALAS_OUTPUT_DIR.
+func OutputDir() string {
+	return os.Getenv("ALAS_OUTPUT_DIR")
+}
+
+// OutputTemplate devuelve la plantilla del nombre de los archivos generados,
+// configurada en ALAS_OUTPUT_TEMPLATE (por ejemplo "{pallet}_{date}").
+func OutputTemplate() string {
+	return os.Getenv("ALAS_OUTPUT_TEMPLATE")
+}
+
+// OutputDaily indica si los archivos se agrupan en una subcarpeta por día.
+func OutputDaily() bool {
+	return parseBool(os.Getenv("ALAS_OUTPUT_DAILY"))
+}
+
+// Overwrite devuelve la política para archivos existentes configurada en
+// ALAS_OVERWRITE (prompt, suffix, overwrite o fail).
+func Overwrite() string {
+	return os.Getenv("ALAS_OVERWRITE")
+}
+
+// Profile devuelve el nombre del perfil configurado en ALAS_PROFILE, que se
+// usa en las plantillas de nombre de archivo.
+func Profile() string {
+	return os.Getenv("ALAS_PROFILE")
+}
+
+// MapOffline indica si los mapas incluyen Leaflet para abrirse sin conexión,
+// configurado en ALAS_MAP_OFFLINE.
+func MapOffline() bool {
+	return parseBool(os.Getenv("ALAS_MAP_OFFLINE"))
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	appDirName             = "alas-tools-cli"
+	historialPallets       = "pallets_recientes.txt"
+	maxPalletsEnHistorial  = 50
+	historialPerfiles      = "perfiles_recientes.txt"
+	maxPerfilesEnHistorial = 20
+)
+
+// StateDir devuelve el directorio donde la aplicación guarda su estado
+// (historial, logs). Respeta XDG_STATE_HOME en Linux y macOS.
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, appDirName), nil
+	}
+
+	if runtime.GOOS == "windows" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(base, appDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", appDirName), nil
+}
+
+func RecentPallets() []string {
+	return readHistory(historialPallets)
+}
+
+// RecordPallets añade los códigos al inicio del historial, sin duplicados y
+// conservando solo los más recientes.
+func RecordPallets(codes []string) error {
+	return recordHistory(historialPallets, codes, maxPalletsEnHistorial)
+}
+
+// RecentProfiles devuelve los perfiles usados recientemente, del más reciente
+// al más antiguo.
+func RecentProfiles() []string {
+	return readHistory(historialPerfiles)
+}
+
+// RecordProfile añade el perfil al inicio del historial de perfiles.
+func RecordProfile(profile string) error {
+	return recordHistory(historialPerfiles, []string{profile}, maxPerfilesEnHistorial)
+}
+
+func readHistory(nombre string) []string {
+	dir, err := StateDir()
+	if err != nil {
+		return nil
+	}
+
+	file, err := os.Open(filepath.Join(dir, nombre))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var valores []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		valor := strings.TrimSpace(scanner.Text())
+		if valor != "" {
+			valores = append(valores, valor)
+		}
+	}
+
+	return valores
+}
+
+// recordHistory añade los valores al inicio de un historial, sin duplicados y
+// conservando solo los max más recientes.
+func recordHistory(nombre string, nuevos []string, max int) error {
+	dir, err := StateDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	vistos := make(map[string]bool)
+	var valores []string
+	for _, valor := range append(nuevos, readHistory(nombre)...) {
+		if valor == "" || vistos[valor] {
+			continue
+		}
+		vistos[valor] = true
+		valores = append(valores, valor)
+	}
+
+	if len(valores) > max {
+		valores = valores[:max]
+	}
+
+	contenido := strings.Join(valores, "\n") + "\n"
+	return os.WriteFile(filepath.Join(dir, nombre), []byte(contenido), 0644)
+}
@@ -0,0 +1,134 @@
+package coords
+
+import (
+	"fmt"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Decimales con los que se comparan las coordenadas cuando las secuencias no
+// identifican sus órdenes (unos 10 cm).
+const compareDecimals = 6
+
+// ComparedStop es una parada en las dos secuencias comparadas. Planned y
+// Alternative son su posición dentro del pallet en cada una, o 0 si no está.
+type ComparedStop struct {
+	OrderID     string  `json:"order_id,omitempty"`
+	PalletCode  string  `json:"pallet,omitempty"`
+	Lat         float64 `json:"lat"`
+	Lon         float64 `json:"lon"`
+	Planned     int     `json:"planned"`
+	Alternative int     `json:"alternative"`
+	// Shift es Alternative - Planned; queda en 0 si falta en alguna.
+	Shift int `json:"shift"`
+}
+
+// SequenceComparison compara la secuencia planificada de las paradas con una
+// alternativa (optimizada o editada a mano).
+type SequenceComparison struct {
+	// MatchedBy indica cómo se emparejaron las paradas: "order_id" o
+	// "coordinates".
+	MatchedBy     string         `json:"matched_by"`
+	PlannedKm     float64        `json:"planned_km"`
+	AlternativeKm float64        `json:"alternative_km"`
+	Stops         []ComparedStop `json:"stops"`
+	// Changed cuenta las paradas de ambas secuencias que cambiaron de
+	// posición.
+	Changed         int `json:"changed"`
+	OnlyPlanned     int `json:"only_planned"`
+	OnlyAlternative int `json:"only_alternative"`
+}
+
+// CompareSequences empareja las paradas de dos secuencias, numeradas por
+// pallet, por su ID de orden si todas lo tienen o, si no, por su pallet y sus
+// coordenadas; las paradas repetidas se emparejan en el orden en que
+// aparecen. Stops sigue el orden planificado, con las paradas que solo están
+// en la alternativa al final. El largo de cada ruta se mide uniendo las
+// paradas de cada pallet en orden.
+func CompareSequences(planned, alternative []models.Coordenada) SequenceComparison {
+	c := SequenceComparison{
+		MatchedBy:     "coordinates",
+		PlannedKm:     RouteLengthKm(planned),
+		AlternativeKm: RouteLengthKm(alternative),
+		Stops:         []ComparedStop{},
+	}
+	if hasOrderIDs(planned) && hasOrderIDs(alternative) {
+		c.MatchedBy = "order_id"
+	}
+	clave := func(coord models.Coordenada) string {
+		if c.MatchedBy == "order_id" {
+			return coord.OrderID
+		}
+		return fmt.Sprintf("%s|%.*f,%.*f", coord.Pallet, compareDecimals, coord.Lat, compareDecimals, coord.Lon)
+	}
+
+	// Cada clave guarda, en orden, las paradas planificadas aún sin pareja
+	pendientes := map[string][]int{}
+	for _, coord := range planned {
+		k := clave(coord)
+		pendientes[k] = append(pendientes[k], len(c.Stops))
+		c.Stops = append(c.Stops, comparedStop(coord))
+		c.Stops[len(c.Stops)-1].Planned = coord.Index
+	}
+	for _, coord := range alternative {
+		k := clave(coord)
+		if cola := pendientes[k]; len(cola) > 0 {
+			c.Stops[cola[0]].Alternative = coord.Index
+			pendientes[k] = cola[1:]
+			continue
+		}
+		c.Stops = append(c.Stops, comparedStop(coord))
+		c.Stops[len(c.Stops)-1].Alternative = coord.Index
+	}
+
+	for i := range c.Stops {
+		s := &c.Stops[i]
+		switch {
+		case s.Alternative == 0:
+			c.OnlyPlanned++
+		case s.Planned == 0:
+			c.OnlyAlternative++
+		default:
+			s.Shift = s.Alternative - s.Planned
+			if s.Shift != 0 {
+				c.Changed++
+			}
+		}
+	}
+	return c
+}
+
+// RouteLengthKm suma la distancia entre paradas consecutivas de un mismo
+// pallet.
+func RouteLengthKm(paradas []models.Coordenada) float64 {
+	total := 0.0
+	for i := 1; i < len(paradas); i++ {
+		if paradas[i].Pallet != paradas[i-1].Pallet {
+			continue
+		}
+		total += geo.HaversineKm(
+			geo.Point{Lat: paradas[i-1].Lat, Lon: paradas[i-1].Lon},
+			geo.Point{Lat: paradas[i].Lat, Lon: paradas[i].Lon},
+		)
+	}
+	return total
+}
+
+func hasOrderIDs(paradas []models.Coordenada) bool {
+	for _, p := range paradas {
+		if p.OrderID == "" {
+			return false
+		}
+	}
+	return len(paradas) > 0
+}
+
+func comparedStop(coord models.Coordenada) ComparedStop {
+	return ComparedStop{
+		OrderID:    coord.OrderID,
+		PalletCode: coord.Pallet,
+		Lat:        coord.Lat,
+		Lon:        coord.Lon,
+	}
+}
@@ -0,0 +1,83 @@
+package coords
+
+import (
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// DefaultConsolidateRadiusM es el radio, en metros, dentro del que se agrupan
+// por defecto las órdenes de un mismo pallet en una sola parada.
+const DefaultConsolidateRadiusM = 10.0
+
+// Consolidate agrupa en una sola parada las órdenes consecutivas de un mismo
+// pallet que están a menos de radiusM metros de la primera orden de la
+// parada. Las órdenes se recorren en el orden recibido y solo se agrupan con
+// la parada anterior, así que la secuencia de entrega no cambia: una orden
+// cercana a una parada anterior pero separada de ella por otras queda como
+// parada propia. Cada parada conserva la posición y las coordenadas de su
+// primera orden y Orders lista todas. Con un radio de 0 o menos no se agrupa
+// nada.
+func Consolidate(coordInfos []models.CoordInfo, radiusM float64) []models.CoordInfo {
+	if radiusM <= 0 {
+		return coordInfos
+	}
+
+	var paradas []models.CoordInfo
+	for _, info := range coordInfos {
+		if len(paradas) > 0 {
+			parada := &paradas[len(paradas)-1]
+			cerca := geo.HaversineKm(geo.Point{Lat: parada.Lat, Lon: parada.Lon}, geo.Point{Lat: info.Lat, Lon: info.Lon})*1000 <= radiusM
+			if parada.PalletCode == info.PalletCode && cerca {
+				if len(parada.Orders) == 0 {
+					parada.Orders = []models.StopOrder{stopOrder(*parada)}
+				}
+				parada.Orders = append(parada.Orders, stopOrder(info))
+				parada.Flags = mergeFlags(parada.Flags, info.Flags)
+				continue
+			}
+		}
+		paradas = append(paradas, info)
+	}
+
+	return paradas
+}
+
+// ConsolidatedCount devuelve cuántas paradas agrupan más de una orden.
+func ConsolidatedCount(paradas []models.CoordInfo) int {
+	count := 0
+	for _, parada := range paradas {
+		if len(parada.Orders) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func stopOrder(info models.CoordInfo) models.StopOrder {
+	return models.StopOrder{
+		OrderID:         info.OrderID,
+		TrackingCode:    info.TrackingCode,
+		CustomerName:    info.CustomerName,
+		VehicleLocation: info.VehicleLocation,
+		Address:         info.Address,
+		Lat:             info.Lat,
+		Lon:             info.Lon,
+	}
+}
+
+func mergeFlags(flags, nuevos []string) []string {
+	flags = append([]string(nil), flags...)
+	for _, flag := range nuevos {
+		repetido := false
+		for _, existente := range flags {
+			if existente == flag {
+				repetido = true
+				break
+			}
+		}
+		if !repetido {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
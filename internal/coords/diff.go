@@ -0,0 +1,198 @@
+package coords
+
+import (
+	"sort"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Una orden que se desplaza menos que esto se considera en el mismo lugar,
+// para no informar diferencias de redondeo.
+const minMoveM = 1.0
+
+// SnapshotOrder es una orden de una extracción guardada o consultada a la
+// API. Lat y Lon quedan en cero cuando la orden no tiene geolocalización.
+type SnapshotOrder struct {
+	OrderID         string  `json:"order_id"`
+	PalletCode      string  `json:"pallet"`
+	VehicleLocation int     `json:"vehicle_location"`
+	Lat             float64 `json:"lat"`
+	Lon             float64 `json:"lon"`
+}
+
+func (o SnapshotOrder) hasCoordinates() bool {
+	return o.Lat != 0 && o.Lon != 0
+}
+
+// MovedOrder es una orden cuyas coordenadas cambiaron entre dos extracciones.
+type MovedOrder struct {
+	Before SnapshotOrder `json:"before"`
+	After  SnapshotOrder `json:"after"`
+	// Change es "moved" si la orden tiene coordenadas en ambas extracciones,
+	// "geolocated" si solo las tiene en la posterior y "lost_geolocation" si
+	// solo en la anterior. DistanceM queda en 0 salvo en "moved".
+	Change    string  `json:"change"`
+	DistanceM float64 `json:"distance_m"`
+}
+
+// ResequencedOrder es una orden cuyo vehicle_location cambió.
+type ResequencedOrder struct {
+	OrderID    string `json:"order_id"`
+	PalletCode string `json:"pallet"`
+	Before     int    `json:"before"`
+	After      int    `json:"after"`
+}
+
+// Diff reúne las diferencias entre dos extracciones.
+type Diff struct {
+	Added       []SnapshotOrder    `json:"added"`
+	Removed     []SnapshotOrder    `json:"removed"`
+	Moved       []MovedOrder       `json:"moved"`
+	Resequenced []ResequencedOrder `json:"resequenced"`
+}
+
+// Empty indica si las extracciones son iguales.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0 && len(d.Resequenced) == 0
+}
+
+// SnapshotFromCoordInfos expande las paradas (consolidadas o no) y las órdenes
+// sin geolocalización de una extracción en una orden por fila.
+func SnapshotFromCoordInfos(paradas []models.CoordInfo, missing []models.MissingOrder) []SnapshotOrder {
+	var orders []SnapshotOrder
+	for _, parada := range paradas {
+		if len(parada.Orders) == 0 {
+			orders = append(orders, SnapshotOrder{
+				OrderID:         parada.OrderID,
+				PalletCode:      parada.PalletCode,
+				VehicleLocation: parada.VehicleLocation,
+				Lat:             parada.Lat,
+				Lon:             parada.Lon,
+			})
+			continue
+		}
+		for _, o := range parada.Orders {
+			orden := SnapshotOrder{
+				OrderID:         o.OrderID,
+				PalletCode:      parada.PalletCode,
+				VehicleLocation: o.VehicleLocation,
+				Lat:             o.Lat,
+				Lon:             o.Lon,
+			}
+			// Las extracciones sin coordenadas por orden usan las de la parada
+			if o.Lat == 0 && o.Lon == 0 {
+				orden.Lat, orden.Lon = parada.Lat, parada.Lon
+			}
+			orders = append(orders, orden)
+		}
+	}
+
+	for _, m := range missing {
+		orders = append(orders, SnapshotOrder{
+			OrderID:         m.OrderID,
+			PalletCode:      m.PalletCode,
+			VehicleLocation: m.VehicleLocation,
+		})
+	}
+
+	return orders
+}
+
+// ExcludeUnlocated quita de orders las órdenes sin geolocalización que no
+// están en other. Se usa cuando other no incluye las órdenes sin
+// geolocalización, como las exportaciones GeoJSON, para no informarlas como
+// agregadas o eliminadas.
+func ExcludeUnlocated(orders, other []SnapshotOrder) []SnapshotOrder {
+	enOtra := map[string]bool{}
+	for _, o := range other {
+		enOtra[o.OrderID] = true
+	}
+
+	filtradas := []SnapshotOrder{}
+	for _, o := range orders {
+		if o.hasCoordinates() || enOtra[o.OrderID] {
+			filtradas = append(filtradas, o)
+		}
+	}
+	return filtradas
+}
+
+// CompareSnapshots compara dos extracciones orden por orden, identificando
+// cada orden por su ID. Las órdenes que ganan o pierden su geolocalización se
+// informan como movidas.
+func CompareSnapshots(before, after []SnapshotOrder) Diff {
+	diff := Diff{
+		Added:       []SnapshotOrder{},
+		Removed:     []SnapshotOrder{},
+		Moved:       []MovedOrder{},
+		Resequenced: []ResequencedOrder{},
+	}
+
+	antes := map[string]SnapshotOrder{}
+	for _, o := range before {
+		antes[o.OrderID] = o
+	}
+	despues := map[string]SnapshotOrder{}
+	for _, o := range after {
+		despues[o.OrderID] = o
+	}
+
+	for _, o := range before {
+		if _, ok := despues[o.OrderID]; !ok {
+			diff.Removed = append(diff.Removed, o)
+		}
+	}
+
+	for _, o := range after {
+		previa, ok := antes[o.OrderID]
+		if !ok {
+			diff.Added = append(diff.Added, o)
+			continue
+		}
+
+		switch {
+		case previa.hasCoordinates() && o.hasCoordinates():
+			distancia := geo.HaversineKm(geo.Point{Lat: previa.Lat, Lon: previa.Lon}, geo.Point{Lat: o.Lat, Lon: o.Lon}) * 1000
+			if distancia >= minMoveM {
+				diff.Moved = append(diff.Moved, MovedOrder{Before: previa, After: o, Change: "moved", DistanceM: distancia})
+			}
+		case o.hasCoordinates():
+			diff.Moved = append(diff.Moved, MovedOrder{Before: previa, After: o, Change: "geolocated"})
+		case previa.hasCoordinates():
+			diff.Moved = append(diff.Moved, MovedOrder{Before: previa, After: o, Change: "lost_geolocation"})
+		}
+
+		if previa.VehicleLocation != o.VehicleLocation {
+			diff.Resequenced = append(diff.Resequenced, ResequencedOrder{
+				OrderID:    o.OrderID,
+				PalletCode: o.PalletCode,
+				Before:     previa.VehicleLocation,
+				After:      o.VehicleLocation,
+			})
+		}
+	}
+
+	sortSnapshot(diff.Added)
+	sortSnapshot(diff.Removed)
+	sort.SliceStable(diff.Moved, func(i, j int) bool {
+		return diff.Moved[i].DistanceM > diff.Moved[j].DistanceM
+	})
+	sort.SliceStable(diff.Resequenced, func(i, j int) bool {
+		if diff.Resequenced[i].PalletCode != diff.Resequenced[j].PalletCode {
+			return diff.Resequenced[i].PalletCode < diff.Resequenced[j].PalletCode
+		}
+		return diff.Resequenced[i].After < diff.Resequenced[j].After
+	})
+
+	return diff
+}
+
+func sortSnapshot(orders []SnapshotOrder) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		if orders[i].PalletCode != orders[j].PalletCode {
+			return orders[i].PalletCode < orders[j].PalletCode
+		}
+		return orders[i].VehicleLocation < orders[j].VehicleLocation
+	})
+}
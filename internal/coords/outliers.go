@@ -0,0 +1,228 @@
+package coords
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// FlagOutlier marca una parada alejada del resto de su pallet.
+const FlagOutlier = "outlier"
+
+// Métodos de detección de paradas atípicas.
+const (
+	OutlierMAD    = "mad"
+	OutlierIQR    = "iqr"
+	OutlierDBSCAN = "dbscan"
+	OutlierOff    = "off"
+)
+
+// OutlierMethods enumera los métodos aceptados por ParseOutlierMethod.
+var OutlierMethods = []string{OutlierMAD, OutlierIQR, OutlierDBSCAN, OutlierOff}
+
+const (
+	// Una parada más cerca que esto del centro de su pallet nunca se marca,
+	// para no señalar variaciones normales dentro de una misma ciudad.
+	outlierMinDistanceKm = 5.0
+	// Desviaciones (MAD escalada) sobre la mediana a partir de las que una
+	// distancia se considera atípica.
+	madThreshold = 3.5
+	// Factor del rango intercuartílico sobre el tercer cuartil.
+	iqrFactor = 1.5
+	// Radio y mínimo de vecinos (incluida la parada) de DBSCAN.
+	dbscanEpsKm     = 10.0
+	dbscanMinPoints = 3
+	// Con menos paradas no hay estadística suficiente.
+	outlierMinStops = 4
+)
+
+// Outlier describe una parada marcada como atípica.
+type Outlier struct {
+	OrderID         string  `json:"order_id"`
+	PalletCode      string  `json:"pallet"`
+	VehicleLocation int     `json:"vehicle_location"`
+	DistanceKm      float64 `json:"distance_km"`
+}
+
+// ParseOutlierMethod valida el método de detección. Un valor vacío equivale
+// a mad.
+func ParseOutlierMethod(value string) (string, error) {
+	method := strings.ToLower(strings.TrimSpace(value))
+	if method == "" {
+		return OutlierMAD, nil
+	}
+	for _, m := range OutlierMethods {
+		if method == m {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("%s (%s)", value, strings.Join(OutlierMethods, ", "))
+}
+
+// DetectOutliers busca, pallet por pallet, las paradas alejadas del resto y
+// les añade FlagOutlier. La distancia de cada parada se mide contra el
+// centroide de medianas del pallet, que no se desplaza por las propias
+// paradas atípicas. Las coordenadas ya marcadas como inválidas, invertidas o
+// con signo incorrecto no participan.
+func DetectOutliers(coordInfos []models.CoordInfo, method string) []Outlier {
+	if method == OutlierOff {
+		return nil
+	}
+
+	pallets := map[string][]int{}
+	var orden []string
+	for i, info := range coordInfos {
+		if hasCoordinateError(info) {
+			continue
+		}
+		if _, ok := pallets[info.PalletCode]; !ok {
+			orden = append(orden, info.PalletCode)
+		}
+		pallets[info.PalletCode] = append(pallets[info.PalletCode], i)
+	}
+
+	var outliers []Outlier
+	for _, pallet := range orden {
+		indices := pallets[pallet]
+		if len(indices) < outlierMinStops {
+			continue
+		}
+
+		puntos := make([]geo.Point, len(indices))
+		for j, i := range indices {
+			puntos[j] = geo.Point{Lat: coordInfos[i].Lat, Lon: coordInfos[i].Lon}
+		}
+
+		centro := medianCentroid(puntos)
+		distancias := make([]float64, len(puntos))
+		for j, p := range puntos {
+			distancias[j] = geo.HaversineKm(centro, p)
+		}
+
+		var atipicas []bool
+		switch method {
+		case OutlierIQR:
+			atipicas = iqrOutliers(distancias)
+		case OutlierDBSCAN:
+			atipicas = dbscanNoise(puntos)
+		default:
+			atipicas = madOutliers(distancias)
+		}
+
+		for j, i := range indices {
+			if !atipicas[j] || distancias[j] < outlierMinDistanceKm {
+				continue
+			}
+			coordInfos[i].Flags = append(coordInfos[i].Flags, FlagOutlier)
+			outliers = append(outliers, Outlier{
+				OrderID:         coordInfos[i].OrderID,
+				PalletCode:      coordInfos[i].PalletCode,
+				VehicleLocation: coordInfos[i].VehicleLocation,
+				DistanceKm:      math.Round(distancias[j]*10) / 10,
+			})
+		}
+	}
+
+	return outliers
+}
+
+func hasCoordinateError(info models.CoordInfo) bool {
+	for _, flag := range info.Flags {
+		switch flag {
+		case FlagInvalidRange, FlagSwappedAxes, FlagSignError:
+			return true
+		}
+	}
+	return false
+}
+
+func medianCentroid(puntos []geo.Point) geo.Point {
+	lats := make([]float64, len(puntos))
+	lons := make([]float64, len(puntos))
+	for i, p := range puntos {
+		lats[i] = p.Lat
+		lons[i] = p.Lon
+	}
+	return geo.Point{Lat: median(lats), Lon: median(lons)}
+}
+
+// madOutliers marca las distancias que superan la mediana en más de
+// madThreshold desviaciones absolutas medianas (escaladas para equivaler a
+// una desviación estándar).
+func madOutliers(distancias []float64) []bool {
+	med := median(distancias)
+	desvios := make([]float64, len(distancias))
+	for i, d := range distancias {
+		desvios[i] = math.Abs(d - med)
+	}
+	mad := 1.4826 * median(desvios)
+
+	atipicas := make([]bool, len(distancias))
+	for i, d := range distancias {
+		atipicas[i] = d > med+madThreshold*mad
+	}
+	return atipicas
+}
+
+// iqrOutliers marca las distancias sobre Q3 + 1,5 × IQR.
+func iqrOutliers(distancias []float64) []bool {
+	q1 := quantile(distancias, 0.25)
+	q3 := quantile(distancias, 0.75)
+	limite := q3 + iqrFactor*(q3-q1)
+
+	atipicas := make([]bool, len(distancias))
+	for i, d := range distancias {
+		atipicas[i] = d > limite
+	}
+	return atipicas
+}
+
+// dbscanNoise marca los puntos que DBSCAN dejaría como ruido: los que no son
+// núcleo (menos de dbscanMinPoints paradas a dbscanEpsKm) ni están al alcance
+// de un núcleo.
+func dbscanNoise(puntos []geo.Point) []bool {
+	vecinos := make([][]int, len(puntos))
+	for i := range puntos {
+		for j := range puntos {
+			if geo.HaversineKm(puntos[i], puntos[j]) <= dbscanEpsKm {
+				vecinos[i] = append(vecinos[i], j)
+			}
+		}
+	}
+
+	ruido := make([]bool, len(puntos))
+	for i := range ruido {
+		ruido[i] = true
+	}
+	for i := range puntos {
+		if len(vecinos[i]) < dbscanMinPoints {
+			continue
+		}
+		for _, j := range vecinos[i] {
+			ruido[j] = false
+		}
+	}
+	return ruido
+}
+
+func median(valores []float64) float64 {
+	return quantile(valores, 0.5)
+}
+
+// quantile interpola linealmente entre los valores ordenados.
+func quantile(valores []float64, q float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+
+	pos := q * float64(len(ordenados)-1)
+	bajo := int(math.Floor(pos))
+	alto := int(math.Ceil(pos))
+	return ordenados[bajo] + (ordenados[alto]-ordenados[bajo])*(pos-float64(bajo))
+}
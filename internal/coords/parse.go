@@ -0,0 +1,317 @@
+package coords
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+// Cantidad máxima de errores que se informan de un mismo archivo.
+const maxParseErrors = 10
+
+// ParsedPoint es un par de coordenadas leído de un archivo de texto, con el
+// comentario que lo acompaña (sin /* */) si lo hay.
+type ParsedPoint struct {
+	Lat     float64
+	Lon     float64
+	Line    int
+	Comment string
+}
+
+// ParseError indica la posición de una entrada mal formada.
+type ParseError struct {
+	Line   int
+	Column int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return i18n.T("parse.position", e.Line, e.Column, e.Reason)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLBracket
+	tokenRBracket
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenNumber
+	tokenComment
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return i18n.T("parse.end")
+	case tokenNumber:
+		return fmt.Sprintf("%s %s", i18n.T("parse.number"), t.text)
+	}
+	return strconv.Quote(t.text)
+}
+
+// ParseCoordinates lee pares de coordenadas "(lat, lon)" en el formato de los
+// archivos de coordenadas, con o sin corchetes y comentarios /* */, // o #. Los
+// pares pueden ir separados por comas o saltos de línea, uno por línea y sin
+// paréntesis. Las entradas mal formadas se informan con su línea y columna.
+func ParseCoordinates(contenido string) ([]ParsedPoint, error) {
+	tokens, errs := tokenize(contenido)
+	p := &parser{tokens: tokens, errs: errs}
+	p.parse()
+	if len(p.errs) > maxParseErrors {
+		p.errs = append(p.errs[:maxParseErrors], errors.New(i18n.T("parse.more_errors", len(p.errs)-maxParseErrors)))
+	}
+	return p.points, errors.Join(p.errs...)
+}
+
+func tokenize(contenido string) ([]token, []error) {
+	var tokens []token
+	var errs []error
+	runes := []rune(contenido)
+	line, column := 1, 1
+
+	advance := func(n int) {
+		for i := 0; i < n; i++ {
+			if runes[0] == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+			runes = runes[1:]
+		}
+	}
+	emit := func(kind tokenKind, text string) {
+		tokens = append(tokens, token{kind: kind, text: text, line: line, column: column})
+	}
+
+	for len(runes) > 0 {
+		r := runes[0]
+		switch {
+		case unicode.IsSpace(r) || r == '\uFEFF':
+			advance(1)
+		case r == '[':
+			emit(tokenLBracket, "[")
+			advance(1)
+		case r == ']':
+			emit(tokenRBracket, "]")
+			advance(1)
+		case r == '(':
+			emit(tokenLParen, "(")
+			advance(1)
+		case r == ')':
+			emit(tokenRParen, ")")
+			advance(1)
+		case r == ',' || r == ';':
+			emit(tokenComma, string(r))
+			advance(1)
+		case hasPrefix(runes, "/*"):
+			fin := 2
+			for fin < len(runes) && !hasPrefix(runes[fin:], "*/") {
+				fin++
+			}
+			if fin == len(runes) {
+				errs = append(errs, &ParseError{Line: line, Column: column, Reason: i18n.T("parse.unclosed_comment")})
+				advance(len(runes))
+				continue
+			}
+			emit(tokenComment, strings.TrimSpace(string(runes[2:fin])))
+			advance(fin + 2)
+		case hasPrefix(runes, "//") || r == '#':
+			n := 0
+			for n < len(runes) && runes[n] != '\n' {
+				n++
+			}
+			emit(tokenComment, strings.TrimSpace(strings.TrimLeft(string(runes[:n]), "/#")))
+			advance(n)
+		case r == '-' || r == '+' || r == '.' || unicode.IsDigit(r):
+			n := 1
+			for n < len(runes) && isNumberRune(runes[n], n > 0 && (runes[n-1] == 'e' || runes[n-1] == 'E')) {
+				n++
+			}
+			emit(tokenNumber, string(runes[:n]))
+			advance(n)
+		default:
+			// Se informa de una vez todo el texto hasta el siguiente separador
+			n := 1
+			for n < len(runes) && !unicode.IsSpace(runes[n]) && !strings.ContainsRune("[](),;", runes[n]) {
+				n++
+			}
+			errs = append(errs, &ParseError{Line: line, Column: column, Reason: i18n.T("parse.unexpected_text", string(runes[:n]))})
+			advance(n)
+		}
+	}
+	emit(tokenEOF, "")
+	return tokens, errs
+}
+
+func hasPrefix(runes []rune, prefijo string) bool {
+	return len(runes) >= 2 && string(runes[:2]) == prefijo
+}
+
+func isNumberRune(r rune, afterExponent bool) bool {
+	return unicode.IsDigit(r) || r == '.' || r == 'e' || r == 'E' || (afterExponent && (r == '-' || r == '+'))
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	points []ParsedPoint
+	errs   []error
+	// commented indica si el último punto ya recibió su comentario.
+	commented bool
+}
+
+// peek devuelve el siguiente token que no es un comentario, asociando los
+// comentarios encontrados al último punto leído.
+func (p *parser) peek() token {
+	for p.tokens[p.pos].kind == tokenComment {
+		if len(p.points) > 0 && !p.commented {
+			p.points[len(p.points)-1].Comment = p.tokens[p.pos].text
+			p.commented = true
+		}
+		p.pos++
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) fail(t token, key string, args ...any) {
+	p.errs = append(p.errs, &ParseError{Line: t.line, Column: t.column, Reason: i18n.T(key, args...)})
+}
+
+func (p *parser) parse() {
+	cerrado := false
+	if p.peek().kind == tokenLBracket {
+		p.next()
+		cerrado = true
+	}
+
+	for {
+		t := p.peek()
+		switch t.kind {
+		case tokenEOF:
+			if cerrado {
+				p.fail(t, "parse.expected", "\"]\"", t.describe())
+			}
+			return
+		case tokenRBracket:
+			p.next()
+			if !cerrado {
+				p.fail(t, "parse.unexpected", t.describe())
+				continue
+			}
+			if fin := p.peek(); fin.kind != tokenEOF {
+				p.fail(fin, "parse.trailing", fin.describe())
+			}
+			return
+		case tokenComma:
+			p.next()
+		case tokenLParen, tokenNumber:
+			p.parsePair()
+		default:
+			p.next()
+			p.fail(t, "parse.unexpected", t.describe())
+		}
+	}
+}
+
+// parsePair lee "(lat, lon)" o "lat, lon". Si el par está mal formado
+// registra el error y salta hasta el final de la entrada.
+func (p *parser) parsePair() {
+	inicio := p.next()
+	parentesis := inicio.kind == tokenLParen
+
+	var lat float64
+	var ok bool
+	if parentesis {
+		lat, ok = p.number()
+	} else {
+		lat, ok = p.parseNumber(inicio)
+	}
+	if ok {
+		// La coma entre latitud y longitud es opcional
+		if p.peek().kind == tokenComma {
+			p.next()
+		}
+		var lon float64
+		lon, ok = p.number()
+		if ok && parentesis {
+			if cierre := p.peek(); cierre.kind == tokenRParen {
+				p.next()
+			} else {
+				p.fail(cierre, "parse.expected", "\")\"", cierre.describe())
+				ok = false
+			}
+		}
+		if ok {
+			p.points = append(p.points, ParsedPoint{Lat: lat, Lon: lon, Line: inicio.line})
+			p.commented = false
+			return
+		}
+	}
+
+	p.skipEntry(inicio, parentesis)
+}
+
+// number lee el siguiente token como número. Si no lo es, no lo consume.
+func (p *parser) number() (float64, bool) {
+	valor, ok := p.parseNumber(p.peek())
+	if ok {
+		p.next()
+	}
+	return valor, ok
+}
+
+func (p *parser) parseNumber(t token) (float64, bool) {
+	if t.kind != tokenNumber {
+		p.fail(t, "parse.expected", i18n.T("parse.number"), t.describe())
+		return 0, false
+	}
+	valor, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		p.fail(t, "parse.invalid_number", t.text)
+		return 0, false
+	}
+	return valor, true
+}
+
+// skipEntry avanza hasta el cierre del paréntesis de la entrada o, si no
+// tenía, hasta la línea siguiente.
+func (p *parser) skipEntry(inicio token, parentesis bool) {
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF || t.kind == tokenRBracket:
+			return
+		case parentesis && t.kind == tokenRParen:
+			p.next()
+			return
+		case parentesis && t.kind == tokenLParen:
+			return
+		case !parentesis && t.line != inicio.line:
+			return
+		}
+		p.next()
+	}
+}
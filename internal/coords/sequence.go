@@ -0,0 +1,148 @@
+package coords
+
+import (
+	"sort"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Tipos de problema en la secuencia de vehicle_location.
+const (
+	SequenceDuplicated = "duplicated"
+	SequenceMissing    = "missing"
+	SequenceZero       = "zero"
+	// SequenceMultiplePallets es una orden que aparece en más de un pallet.
+	SequenceMultiplePallets = "multiple_pallets"
+)
+
+// SequenceOrder es una orden tal como llega de la API, con o sin coordenadas,
+// para revisar su vehicle_location.
+type SequenceOrder struct {
+	OrderID         string
+	PalletCode      string
+	VehicleLocation int
+}
+
+// CheckSequence revisa que los vehicle_location de cada pallet sean únicos,
+// distintos de cero y sin saltos entre 1 y el mayor valor. Con varios pallets
+// revisa además que ninguna orden aparezca en más de uno; los valores se
+// repiten entre pallets porque cada uno numera su propia secuencia.
+func CheckSequence(orders []SequenceOrder) []models.SequenceIssue {
+	pallets := map[string][]SequenceOrder{}
+	var orden []string
+	for _, o := range orders {
+		if _, ok := pallets[o.PalletCode]; !ok {
+			orden = append(orden, o.PalletCode)
+		}
+		pallets[o.PalletCode] = append(pallets[o.PalletCode], o)
+	}
+
+	var issues []models.SequenceIssue
+	for _, pallet := range orden {
+		issues = append(issues, checkPallet(pallet, pallets[pallet])...)
+	}
+
+	if len(orden) > 1 {
+		issues = append(issues, checkCombined(orders)...)
+	}
+
+	return issues
+}
+
+func checkPallet(pallet string, orders []SequenceOrder) []models.SequenceIssue {
+	var issues []models.SequenceIssue
+	porValor := groupByVehicleLocation(orders)
+
+	if ceros, ok := porValor[0]; ok {
+		issues = append(issues, models.SequenceIssue{
+			Pallet:   pallet,
+			Kind:     SequenceZero,
+			OrderIDs: orderIDs(ceros),
+		})
+	}
+
+	for _, vl := range sortedValues(porValor) {
+		if vl != 0 && len(porValor[vl]) > 1 {
+			issues = append(issues, models.SequenceIssue{
+				Pallet:          pallet,
+				Kind:            SequenceDuplicated,
+				VehicleLocation: vl,
+				OrderIDs:        orderIDs(porValor[vl]),
+			})
+		}
+	}
+
+	return append(issues, missingValues(pallet, porValor)...)
+}
+
+func checkCombined(orders []SequenceOrder) []models.SequenceIssue {
+	pallets := map[string]map[string]bool{}
+	var ids []string
+	for _, o := range orders {
+		if o.OrderID == "" {
+			continue
+		}
+		if pallets[o.OrderID] == nil {
+			pallets[o.OrderID] = map[string]bool{}
+			ids = append(ids, o.OrderID)
+		}
+		pallets[o.OrderID][o.PalletCode] = true
+	}
+
+	var issues []models.SequenceIssue
+	for _, id := range ids {
+		if len(pallets[id]) > 1 {
+			issues = append(issues, models.SequenceIssue{
+				Kind:     SequenceMultiplePallets,
+				OrderIDs: []string{id},
+			})
+		}
+	}
+	return issues
+}
+
+func missingValues(pallet string, porValor map[int][]SequenceOrder) []models.SequenceIssue {
+	maximo := 0
+	for vl := range porValor {
+		if vl > maximo {
+			maximo = vl
+		}
+	}
+
+	var issues []models.SequenceIssue
+	for vl := 1; vl < maximo; vl++ {
+		if _, ok := porValor[vl]; !ok {
+			issues = append(issues, models.SequenceIssue{
+				Pallet:          pallet,
+				Kind:            SequenceMissing,
+				VehicleLocation: vl,
+			})
+		}
+	}
+	return issues
+}
+
+func groupByVehicleLocation(orders []SequenceOrder) map[int][]SequenceOrder {
+	porValor := map[int][]SequenceOrder{}
+	for _, o := range orders {
+		porValor[o.VehicleLocation] = append(porValor[o.VehicleLocation], o)
+	}
+	return porValor
+}
+
+func sortedValues(porValor map[int][]SequenceOrder) []int {
+	var valores []int
+	for vl := range porValor {
+		valores = append(valores, vl)
+	}
+	sort.Ints(valores)
+	return valores
+}
+
+func orderIDs(orders []SequenceOrder) []string {
+	var ids []string
+	for _, o := range orders {
+		ids = append(ids, o.OrderID)
+	}
+	return ids
+}
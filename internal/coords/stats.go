@@ -0,0 +1,77 @@
+package coords
+
+import (
+	"math"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Con una envolvente menor (paradas casi alineadas) la densidad no tiene
+// sentido y se deja en 0.
+const minHullAreaKm2 = 0.001
+
+// RouteStats resume la ruta de un pallet recorrida en el orden actual de
+// vehicle_location.
+type RouteStats struct {
+	Pallet       string    `json:"pallet"`
+	Stops        int       `json:"stops"`
+	LengthKm     float64   `json:"length_km"`
+	LongestLegKm float64   `json:"longest_leg_km"`
+	AvgLegKm     float64   `json:"avg_leg_km"`
+	BBox         geo.BBox  `json:"bbox"`
+	Centroid     geo.Point `json:"centroid"`
+	HullAreaKm2  float64   `json:"hull_area_km2"`
+	// StopsPerKm2 es la cantidad de paradas por km² de la envolvente
+	// convexa; queda en 0 cuando el área es despreciable.
+	StopsPerKm2 float64 `json:"stops_per_km2"`
+}
+
+// PalletStats calcula las estadísticas de ruta de cada pallet sobre sus
+// paradas en el orden recibido. Las coordenadas marcadas como inválidas,
+// invertidas o con signo incorrecto no se consideran.
+func PalletStats(paradas []models.CoordInfo) []RouteStats {
+	pallets := map[string][]geo.Point{}
+	var orden []string
+	for _, info := range paradas {
+		if hasCoordinateError(info) {
+			continue
+		}
+		if _, ok := pallets[info.PalletCode]; !ok {
+			orden = append(orden, info.PalletCode)
+		}
+		pallets[info.PalletCode] = append(pallets[info.PalletCode], geo.Point{Lat: info.Lat, Lon: info.Lon})
+	}
+
+	var stats []RouteStats
+	for _, pallet := range orden {
+		stats = append(stats, routeStats(pallet, pallets[pallet]))
+	}
+	return stats
+}
+
+func routeStats(pallet string, puntos []geo.Point) RouteStats {
+	s := RouteStats{
+		Pallet:      pallet,
+		Stops:       len(puntos),
+		BBox:        geo.Bounds(puntos),
+		Centroid:    geo.Centroid(puntos),
+		HullAreaKm2: geo.ConvexHullAreaKm2(puntos),
+	}
+
+	for i := 1; i < len(puntos); i++ {
+		tramo := geo.HaversineKm(puntos[i-1], puntos[i])
+		s.LengthKm += tramo
+		s.LongestLegKm = math.Max(s.LongestLegKm, tramo)
+	}
+	if len(puntos) > 1 {
+		s.AvgLegKm = s.LengthKm / float64(len(puntos)-1)
+	}
+	if s.HullAreaKm2 < minHullAreaKm2 {
+		s.HullAreaKm2 = 0
+	} else {
+		s.StopsPerKm2 = float64(s.Stops) / s.HullAreaKm2
+	}
+
+	return s
+}
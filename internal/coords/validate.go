@@ -0,0 +1,85 @@
+package coords
+
+import (
+	"sort"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Códigos de motivo con que se marcan las órdenes con coordenadas dudosas.
+const (
+	FlagInvalidRange = "invalid_range"
+	FlagSwappedAxes  = "swapped_axes"
+	FlagSignError    = "sign_error"
+	FlagOutOfRegion  = "out_of_region"
+)
+
+// FlagCodes enumera todos los códigos de motivo conocidos.
+var FlagCodes = []string{
+	FlagInvalidRange,
+	FlagSwappedAxes,
+	FlagSignError,
+	FlagOutOfRegion,
+	FlagOutlier,
+}
+
+// Validate revisa cada coordenada contra la región y añade a Flags el motivo
+// cuando no está dentro: rango inválido, latitud y longitud invertidas, signo
+// incorrecto o simplemente fuera de la región. Devuelve la cantidad de
+// órdenes marcadas por motivo.
+func Validate(coordInfos []models.CoordInfo, region geo.Region) map[string]int {
+	counts := map[string]int{}
+
+	for i := range coordInfos {
+		flag := checkPoint(geo.Point{Lat: coordInfos[i].Lat, Lon: coordInfos[i].Lon}, region)
+		if flag == "" {
+			continue
+		}
+		coordInfos[i].Flags = append(coordInfos[i].Flags, flag)
+		counts[flag]++
+	}
+
+	return counts
+}
+
+func checkPoint(p geo.Point, region geo.Region) string {
+	if !geo.ValidRange(p) {
+		if region.Contains(geo.Point{Lat: p.Lon, Lon: p.Lat}) {
+			return FlagSwappedAxes
+		}
+		return FlagInvalidRange
+	}
+
+	if region.Contains(p) {
+		return ""
+	}
+
+	if region.Contains(geo.Point{Lat: p.Lon, Lon: p.Lat}) {
+		return FlagSwappedAxes
+	}
+
+	for _, candidato := range []geo.Point{
+		{Lat: -p.Lat, Lon: p.Lon},
+		{Lat: p.Lat, Lon: -p.Lon},
+		{Lat: -p.Lat, Lon: -p.Lon},
+		{Lat: -p.Lon, Lon: -p.Lat},
+	} {
+		if region.Contains(candidato) {
+			return FlagSignError
+		}
+	}
+
+	return FlagOutOfRegion
+}
+
+// SortedFlags devuelve los motivos de un conteo en orden alfabético, para
+// mostrarlos de forma estable.
+func SortedFlags(counts map[string]int) []string {
+	var flags []string
+	for flag := range counts {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	return flags
+}
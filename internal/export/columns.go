@@ -0,0 +1,175 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Columnas disponibles para las exportaciones tabulares (CSV y XLSX).
+const (
+	ColumnPallet          = "pallet"
+	ColumnSequence        = "sequence"
+	ColumnVehicleLocation = "vehicle_location"
+	ColumnOrderID         = "order_id"
+	ColumnOrders          = "orders"
+	ColumnTrackingCode    = "tracking_code"
+	ColumnCustomerName    = "customer_name"
+	ColumnAddress         = "address"
+	ColumnCommune         = "commune"
+	ColumnLat             = "lat"
+	ColumnLon             = "lon"
+	ColumnFlags           = "flags"
+)
+
+const coordinateDecimals = 7
+
+var DefaultColumns = []string{
+	ColumnPallet,
+	ColumnVehicleLocation,
+	ColumnOrderID,
+	ColumnOrders,
+	ColumnAddress,
+	ColumnCommune,
+	ColumnLat,
+	ColumnLon,
+	ColumnFlags,
+}
+
+type column struct {
+	header  string
+	numeric bool
+	value   func(seq int, info models.CoordInfo) string
+}
+
+var columns = map[string]column{
+	ColumnPallet: {
+		header: "export.col.pallet",
+		value:  func(seq int, info models.CoordInfo) string { return info.PalletCode },
+	},
+	ColumnSequence: {
+		header:  "export.col.sequence",
+		numeric: true,
+		value:   func(seq int, info models.CoordInfo) string { return strconv.Itoa(seq) },
+	},
+	ColumnVehicleLocation: {
+		header:  "export.col.vehicle_location",
+		numeric: true,
+		value:   func(seq int, info models.CoordInfo) string { return joinInts(info.VehicleLocations(), ";") },
+	},
+	ColumnOrderID: {
+		header: "export.col.order_id",
+		value:  func(seq int, info models.CoordInfo) string { return strings.Join(info.OrderIDs(), ";") },
+	},
+	ColumnOrders: {
+		header:  "export.col.orders",
+		numeric: true,
+		value:   func(seq int, info models.CoordInfo) string { return strconv.Itoa(info.OrderCount()) },
+	},
+	ColumnTrackingCode: {
+		header: "export.col.tracking_code",
+		value:  func(seq int, info models.CoordInfo) string { return strings.Join(info.TrackingCodes(), ";") },
+	},
+	ColumnCustomerName: {
+		header: "export.col.customer_name",
+		value:  func(seq int, info models.CoordInfo) string { return strings.Join(info.CustomerNames(), ";") },
+	},
+	ColumnAddress: {
+		header: "export.col.address",
+		value:  func(seq int, info models.CoordInfo) string { return info.Address },
+	},
+	ColumnCommune: {
+		header: "export.col.commune",
+		value:  func(seq int, info models.CoordInfo) string { return info.Commune },
+	},
+	ColumnLat: {
+		header:  "export.col.lat",
+		numeric: true,
+		value:   func(seq int, info models.CoordInfo) string { return formatCoordinate(info.Lat) },
+	},
+	ColumnLon: {
+		header:  "export.col.lon",
+		numeric: true,
+		value:   func(seq int, info models.CoordInfo) string { return formatCoordinate(info.Lon) },
+	},
+	ColumnFlags: {
+		header: "export.col.flags",
+		value:  func(seq int, info models.CoordInfo) string { return strings.Join(info.Flags, ";") },
+	},
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', coordinateDecimals, 64)
+}
+
+func joinInts(values []int, sep string) string {
+	var partes []string
+	for _, v := range values {
+		partes = append(partes, strconv.Itoa(v))
+	}
+	return strings.Join(partes, sep)
+}
+
+// ParseColumns convierte una lista separada por comas en columnas válidas.
+// Una lista vacía equivale a DefaultColumns.
+func ParseColumns(value string) ([]string, error) {
+	var result []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%s (%s)", name, strings.Join(supportedColumns(), ", "))
+		}
+		result = append(result, name)
+	}
+
+	if len(result) == 0 {
+		return DefaultColumns, nil
+	}
+	return result, nil
+}
+
+func supportedColumns() []string {
+	return []string{
+		ColumnPallet,
+		ColumnSequence,
+		ColumnVehicleLocation,
+		ColumnOrderID,
+		ColumnOrders,
+		ColumnTrackingCode,
+		ColumnCustomerName,
+		ColumnAddress,
+		ColumnCommune,
+		ColumnLat,
+		ColumnLon,
+		ColumnFlags,
+	}
+}
+
+func selectedColumns(opts Options) []string {
+	if len(opts.Columns) == 0 {
+		return DefaultColumns
+	}
+	return opts.Columns
+}
+
+func headerRow(names []string) []string {
+	var row []string
+	for _, name := range names {
+		row = append(row, i18n.T(columns[name].header))
+	}
+	return row
+}
+
+func valueRow(names []string, seq int, info models.CoordInfo) []string {
+	var row []string
+	for _, name := range names {
+		row = append(row, columns[name].value(seq, info))
+	}
+	return row
+}
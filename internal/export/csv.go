@@ -0,0 +1,48 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// utf8BOM permite que Excel detecte la codificación y muestre bien los
+// acentos de las direcciones.
+const utf8BOM = "\ufeff"
+
+// WriteCSV guarda una fila por orden con las columnas seleccionadas y una
+// fila de encabezado. Las coordenadas usan punto decimal y siete decimales.
+func WriteCSV(fileName string, coordInfos []models.CoordInfo, opts Options) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	names := selectedColumns(opts)
+	w := csv.NewWriter(file)
+
+	if err := w.Write(headerRow(names)); err != nil {
+		return err
+	}
+
+	// La secuencia se numera dentro de cada pallet
+	for _, group := range groupByPallet(coordInfos) {
+		for seq, info := range group.orders {
+			if err := w.Write(valueRow(names, seq+1, info)); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const (
+	FormatGeoJSON = "geojson"
+	FormatCSV     = "csv"
+	FormatXLSX    = "xlsx"
+	FormatKML     = "kml"
+	FormatGPX     = "gpx"
+)
+
+// Options controla qué formatos adicionales se generan junto a los archivos
+// de texto de coordenadas.
+type Options struct {
+	Formats []string
+	Route   bool
+	Columns []string
+}
+
+type writer struct {
+	extension string
+	write     func(fileName string, coordInfos []models.CoordInfo, opts Options) error
+}
+
+var writers = map[string]writer{
+	FormatGeoJSON: {
+		extension: ".geojson",
+		write: func(fileName string, coordInfos []models.CoordInfo, opts Options) error {
+			return WriteGeoJSON(fileName, coordInfos, opts.Route)
+		},
+	},
+	FormatCSV: {
+		extension: ".csv",
+		write:     WriteCSV,
+	},
+	FormatXLSX: {
+		extension: ".xlsx",
+		write:     WriteXLSX,
+	},
+	FormatKML: {
+		extension: ".kml",
+		write:     WriteKML,
+	},
+	FormatGPX: {
+		extension: ".gpx",
+		write:     WriteGPX,
+	},
+}
+
+func SupportedFormats() []string {
+	var formats []string
+	for format := range writers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ParseFormats convierte una lista separada por comas ("geojson,csv") en los
+// formatos correspondientes, rechazando los desconocidos.
+func ParseFormats(value string) ([]string, error) {
+	var formats []string
+	for _, format := range strings.Split(value, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		if _, ok := writers[format]; !ok {
+			return nil, fmt.Errorf("%s (%s)", format, strings.Join(SupportedFormats(), ", "))
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// Write genera el archivo del formato indicado usando baseName como nombre
+// sin extensión y devuelve el nombre del archivo creado.
+func Write(format, baseName string, coordInfos []models.CoordInfo, opts Options) (string, error) {
+	w, ok := writers[format]
+	if !ok {
+		return "", fmt.Errorf("%s (%s)", format, strings.Join(SupportedFormats(), ", "))
+	}
+
+	fileName := baseName + w.extension
+	if err := w.write(fileName, coordInfos, opts); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
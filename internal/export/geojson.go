@@ -0,0 +1,106 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// WriteGeoJSON guarda las coordenadas como un FeatureCollection con un Point
+// por orden, agrupadas por pallet y con la secuencia dentro de su pallet. Con
+// route se añade además un LineString por pallet que une sus paradas en ese
+// mismo orden.
+func WriteGeoJSON(fileName string, coordInfos []models.CoordInfo, route bool) error {
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []geoJSONFeature{},
+	}
+
+	var rutas []geoJSONFeature
+	for _, group := range groupByPallet(coordInfos) {
+		var linea [][2]float64
+
+		for seq, info := range group.orders {
+			// GeoJSON usa el orden [longitud, latitud]
+			punto := [2]float64{info.Lon, info.Lat}
+			linea = append(linea, punto)
+
+			properties := map[string]any{
+				"order_id":         info.OrderID,
+				"pallet":           info.PalletCode,
+				"vehicle_location": info.VehicleLocation,
+				"sequence":         seq + 1,
+				"address":          info.Address,
+			}
+			if info.TrackingCode != "" {
+				properties["tracking_code"] = info.TrackingCode
+			}
+			if info.CustomerName != "" {
+				properties["customer_name"] = info.CustomerName
+			}
+			if len(info.Flags) > 0 {
+				properties["flags"] = info.Flags
+			}
+			if len(info.Orders) > 0 {
+				properties["orders"] = info.OrderCount()
+				properties["order_ids"] = info.OrderIDs()
+				properties["vehicle_locations"] = info.VehicleLocations()
+				if info.TrackingCode != "" {
+					properties["tracking_codes"] = info.TrackingCodes()
+				}
+				if info.CustomerName != "" {
+					properties["customer_names"] = info.CustomerNames()
+				}
+			}
+
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type: "Feature",
+				Geometry: geoJSONGeometry{
+					Type:        "Point",
+					Coordinates: punto,
+				},
+				Properties: properties,
+			})
+		}
+
+		if route && len(linea) >= 2 {
+			rutas = append(rutas, geoJSONFeature{
+				Type: "Feature",
+				Geometry: geoJSONGeometry{
+					Type:        "LineString",
+					Coordinates: linea,
+				},
+				Properties: map[string]any{
+					"type":   "route",
+					"pallet": group.code,
+					"stops":  len(linea),
+				},
+			})
+		}
+	}
+	collection.Features = append(collection.Features, rutas...)
+
+	contenido, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, contenido, 0644)
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Routes    []gpxRoute    `xml:"rte"`
+	Tracks    []gpxTrack    `xml:"trk"`
+}
+
+type gpxWaypoint struct {
+	Lat  string `xml:"lat,attr"`
+	Lon  string `xml:"lon,attr"`
+	Name string `xml:"name"`
+	Desc string `xml:"desc,omitempty"`
+	Type string `xml:"type,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string        `xml:"name"`
+	Points []gpxWaypoint `xml:"rtept"`
+}
+
+type gpxTrack struct {
+	Name    string `xml:"name"`
+	Segment struct {
+		Points []gpxWaypoint `xml:"trkpt"`
+	} `xml:"trkseg"`
+}
+
+// WriteGPX guarda un waypoint por orden y, por cada pallet, una ruta y un
+// track con las paradas en el orden recibido (vehicle_location).
+func WriteGPX(fileName string, coordInfos []models.CoordInfo, opts Options) error {
+	doc := gpxDocument{
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Version: "1.1",
+		Creator: "Alas-Tools-Cli",
+	}
+
+	for _, group := range groupByPallet(coordInfos) {
+		nombre := i18n.T("export.route_name", palletName(group.code))
+		route := gpxRoute{Name: nombre}
+		track := gpxTrack{Name: nombre}
+
+		for seq, info := range group.orders {
+			punto := gpxWaypoint{
+				Lat:  formatCoordinate(info.Lat),
+				Lon:  formatCoordinate(info.Lon),
+				Name: stopName(seq+1, info),
+			}
+
+			waypoint := punto
+			waypoint.Desc = stopDescription(info)
+			waypoint.Type = palletName(group.code)
+			doc.Waypoints = append(doc.Waypoints, waypoint)
+
+			route.Points = append(route.Points, punto)
+			track.Segment.Points = append(track.Segment.Points, gpxWaypoint{
+				Lat:  punto.Lat,
+				Lon:  punto.Lon,
+				Name: fmt.Sprintf("%d", seq+1),
+			})
+		}
+
+		doc.Routes = append(doc.Routes, route)
+		doc.Tracks = append(doc.Tracks, track)
+	}
+
+	return writeXMLFile(fileName, doc)
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const IndexSuffix = "_indice.csv"
+
+// WriteIndex guarda en CSV el índice de una extracción de varios pallets, con
+// una fila por pallet y sus archivos separados por ";".
+func WriteIndex(fileName string, pallets []models.PalletFiles) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{
+		i18n.T("export.col.pallet"),
+		i18n.T("index.col.orders"),
+		i18n.T("stats.col.stops"),
+		i18n.T("index.col.missing"),
+		i18n.T("index.col.files"),
+	})
+
+	for _, p := range pallets {
+		w.Write([]string{
+			p.Pallet,
+			strconv.Itoa(p.Orders),
+			strconv.Itoa(p.Stops),
+			strconv.Itoa(p.MissingGeolocation),
+			strings.Join(p.Files, ";"),
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+type kmlDocument struct {
+	XMLName xml.Name `xml:"kml"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Doc     struct {
+		Name    string      `xml:"name"`
+		Styles  []kmlStyle  `xml:"Style"`
+		Folders []kmlFolder `xml:"Folder"`
+	} `xml:"Document"`
+}
+
+type kmlStyle struct {
+	ID        string `xml:"id,attr"`
+	IconStyle struct {
+		Color string  `xml:"color"`
+		Scale float64 `xml:"scale"`
+		Icon  struct {
+			Href string `xml:"href"`
+		} `xml:"Icon"`
+	} `xml:"IconStyle"`
+	LineStyle struct {
+		Color string `xml:"color"`
+		Width int    `xml:"width"`
+	} `xml:"LineStyle"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	StyleURL    string         `xml:"styleUrl"`
+	Point       *kmlPoint      `xml:"Point,omitempty"`
+	LineString  *kmlLineString `xml:"LineString,omitempty"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+const kmlIcon = "https://maps.google.com/mapfiles/kml/paddle/wht-blank.png"
+
+// WriteKML guarda una carpeta por pallet con un placemark por orden, con el
+// color del pallet, y la línea de la ruta en el orden recibido.
+func WriteKML(fileName string, coordInfos []models.CoordInfo, opts Options) error {
+	doc := kmlDocument{Xmlns: "http://www.opengis.net/kml/2.2"}
+	doc.Doc.Name = strings.TrimSuffix(filepath.Base(fileName), ".kml")
+
+	for i, group := range groupByPallet(coordInfos) {
+		styleID := fmt.Sprintf("pallet%d", i+1)
+
+		var style kmlStyle
+		style.ID = styleID
+		style.IconStyle.Color = kmlColor(PalletColor(i))
+		style.IconStyle.Scale = 1
+		style.IconStyle.Icon.Href = kmlIcon
+		style.LineStyle.Color = kmlColor(PalletColor(i))
+		style.LineStyle.Width = 3
+		doc.Doc.Styles = append(doc.Doc.Styles, style)
+
+		folder := kmlFolder{Name: palletName(group.code)}
+		var linea []string
+
+		for seq, info := range group.orders {
+			coords := fmt.Sprintf("%s,%s,0", formatCoordinate(info.Lon), formatCoordinate(info.Lat))
+			linea = append(linea, coords)
+
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:        stopName(seq+1, info),
+				Description: kmlDescription(info),
+				StyleURL:    "#" + styleID,
+				Point:       &kmlPoint{Coordinates: coords},
+			})
+		}
+
+		if len(linea) >= 2 {
+			folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+				Name:       i18n.T("export.route_name", palletName(group.code)),
+				StyleURL:   "#" + styleID,
+				LineString: &kmlLineString{Tessellate: 1, Coordinates: strings.Join(linea, " ")},
+			})
+		}
+
+		doc.Doc.Folders = append(doc.Doc.Folders, folder)
+	}
+
+	return writeXMLFile(fileName, doc)
+}
+
+// kmlColor convierte "#rrggbb" al formato aabbggrr de KML.
+func kmlColor(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	return "ff" + hex[4:6] + hex[2:4] + hex[0:2]
+}
+
+func palletName(code string) string {
+	if code == "" {
+		return i18n.T("export.sheet_default")
+	}
+	return code
+}
+
+func stopName(seq int, info models.CoordInfo) string {
+	if len(info.Orders) > 0 {
+		return fmt.Sprintf("%d - %s (%d)", seq, strings.Join(info.OrderIDs(), ", "), info.OrderCount())
+	}
+	if info.OrderID == "" {
+		return fmt.Sprintf("%d", seq)
+	}
+	return fmt.Sprintf("%d - %s", seq, info.OrderID)
+}
+
+func stopDescription(info models.CoordInfo) string {
+	partes := []string{i18n.T("export.col.vehicle_location") + ": " + joinInts(info.VehicleLocations(), ", ")}
+	if len(info.Orders) > 0 {
+		partes = append(partes, i18n.T("export.col.orders")+": "+fmt.Sprint(info.OrderCount()))
+	}
+	if info.Address != "" {
+		partes = append(partes, info.Address)
+	}
+	if info.Commune != "" {
+		partes = append(partes, info.Commune)
+	}
+	if len(info.Flags) > 0 {
+		partes = append(partes, i18n.T("export.col.flags")+": "+strings.Join(info.Flags, ", "))
+	}
+	return strings.Join(partes, "\n")
+}
+
+// kmlDescription usa saltos de línea HTML, ya que Google Earth interpreta la
+// descripción como HTML.
+func kmlDescription(info models.CoordInfo) string {
+	lineas := strings.Split(stopDescription(info), "\n")
+	for i, linea := range lineas {
+		lineas[i] = html.EscapeString(linea)
+	}
+	return strings.Join(lineas, "<br>")
+}
+
+func writeXMLFile(fileName string, v any) error {
+	contenido, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append([]byte(xml.Header), contenido...), 0644)
+}
@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const MissingReportSuffix = "_sin_geolocalizacion.csv"
+
+// WriteMissingReport guarda en CSV las órdenes sin geolocalización para
+// enviarlas a corrección de X&Y.
+func WriteMissingReport(fileName string, missing []models.MissingOrder) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{
+		i18n.T("export.col.order_id"),
+		i18n.T("export.col.pallet"),
+		i18n.T("export.col.vehicle_location"),
+		i18n.T("export.col.address"),
+		i18n.T("export.col.commune"),
+	})
+
+	for _, order := range missing {
+		w.Write([]string{
+			order.OrderID,
+			order.PalletCode,
+			strconv.Itoa(order.VehicleLocation),
+			order.Address,
+			order.Commune,
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,37 @@
+package export
+
+import "github.com/Cait-dev/alas-tools-cli/internal/models"
+
+// PalletColors es la paleta que se asigna a los pallets en orden de aparición.
+var PalletColors = []string{
+	"#e6194b", "#3cb44b", "#4363d8", "#f58231", "#911eb4",
+	"#42d4f4", "#f032e6", "#9a6324", "#800000", "#469990",
+}
+
+func PalletColor(i int) string {
+	return PalletColors[i%len(PalletColors)]
+}
+
+type palletGroup struct {
+	code   string
+	orders []models.CoordInfo
+}
+
+// groupByPallet agrupa las órdenes por pallet conservando el orden en que
+// aparece cada pallet y el orden de las órdenes dentro de él.
+func groupByPallet(coordInfos []models.CoordInfo) []palletGroup {
+	var groups []palletGroup
+	indices := map[string]int{}
+
+	for _, info := range coordInfos {
+		i, ok := indices[info.PalletCode]
+		if !ok {
+			i = len(groups)
+			indices[info.PalletCode] = i
+			groups = append(groups, palletGroup{code: info.PalletCode})
+		}
+		groups[i].orders = append(groups[i].orders, info)
+	}
+
+	return groups
+}
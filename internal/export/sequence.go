@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const SequenceReportSuffix = "_vehicle_location.csv"
+
+// WriteSequenceReport guarda en CSV los problemas encontrados en la secuencia
+// de vehicle_location, uno por fila.
+func WriteSequenceReport(fileName string, issues []models.SequenceIssue) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{
+		i18n.T("export.col.pallet"),
+		i18n.T("export.col.issue"),
+		i18n.T("export.col.vehicle_location"),
+		i18n.T("export.col.order_id"),
+	})
+
+	for _, issue := range issues {
+		pallet := issue.Pallet
+		if pallet == "" {
+			pallet = i18n.T("sequence.all_pallets")
+		}
+		vehicleLocation := strconv.Itoa(issue.VehicleLocation)
+		if issue.Kind == coords.SequenceMultiplePallets {
+			vehicleLocation = ""
+		}
+		w.Write([]string{
+			pallet,
+			i18n.T("sequence.kind." + issue.Kind),
+			vehicleLocation,
+			strings.Join(issue.OrderIDs, ";"),
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+const StatsReportSuffix = "_estadisticas.csv"
+
+// WriteStatsReport guarda en CSV las estadísticas de ruta, una fila por
+// pallet. Las distancias y áreas usan tres decimales.
+func WriteStatsReport(fileName string, stats []coords.RouteStats) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(utf8BOM); err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{
+		i18n.T("export.col.pallet"),
+		i18n.T("stats.col.stops"),
+		i18n.T("stats.col.length"),
+		i18n.T("stats.col.longest_leg"),
+		i18n.T("stats.col.avg_leg"),
+		i18n.T("stats.col.min_lat"),
+		i18n.T("stats.col.min_lon"),
+		i18n.T("stats.col.max_lat"),
+		i18n.T("stats.col.max_lon"),
+		i18n.T("stats.col.centroid_lat"),
+		i18n.T("stats.col.centroid_lon"),
+		i18n.T("stats.col.hull_area"),
+		i18n.T("stats.col.density"),
+	})
+
+	for _, s := range stats {
+		w.Write([]string{
+			s.Pallet,
+			strconv.Itoa(s.Stops),
+			formatMeasure(s.LengthKm),
+			formatMeasure(s.LongestLegKm),
+			formatMeasure(s.AvgLegKm),
+			formatCoordinate(s.BBox.MinLat),
+			formatCoordinate(s.BBox.MinLon),
+			formatCoordinate(s.BBox.MaxLat),
+			formatCoordinate(s.BBox.MaxLon),
+			formatCoordinate(s.Centroid.Lat),
+			formatCoordinate(s.Centroid.Lon),
+			formatMeasure(s.HullAreaKm2),
+			formatMeasure(s.StopsPerKm2),
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func formatMeasure(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
@@ -0,0 +1,251 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const maxSheetNameLength = 31
+
+type xlsxSheet struct {
+	name   string
+	orders []models.CoordInfo
+}
+
+// WriteXLSX guarda un libro de Excel con una hoja por pallet. Se escribe
+// directamente el formato Office Open XML mínimo, sin dependencias externas.
+func WriteXLSX(fileName string, coordInfos []models.CoordInfo, opts Options) error {
+	sheets := groupSheets(coordInfos)
+	names := selectedColumns(opts)
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+
+	partes := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+
+	for _, parte := range partes {
+		if err := writeZipEntry(zw, parte.name, parte.content); err != nil {
+			return err
+		}
+	}
+
+	for i, sheet := range sheets {
+		w, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if err := writeXLSXSheet(w, names, sheet.orders); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// groupSheets crea una hoja por pallet con nombres de hoja válidos y únicos.
+func groupSheets(coordInfos []models.CoordInfo) []xlsxSheet {
+	var sheets []xlsxSheet
+	for _, group := range groupByPallet(coordInfos) {
+		sheets = append(sheets, xlsxSheet{name: group.code, orders: group.orders})
+	}
+
+	if len(sheets) == 0 {
+		sheets = append(sheets, xlsxSheet{})
+	}
+
+	usados := map[string]bool{}
+	for i := range sheets {
+		name := sanitizeSheetName(sheets[i].name)
+		if name == "" {
+			name = i18n.T("export.sheet_default")
+		}
+		base := name
+		for n := 2; usados[strings.ToLower(name)]; n++ {
+			sufijo := fmt.Sprintf(" (%d)", n)
+			name = truncate(base, maxSheetNameLength-len(sufijo)) + sufijo
+		}
+		usados[strings.ToLower(name)] = true
+		sheets[i].name = name
+	}
+
+	return sheets
+}
+
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	return truncate(strings.Trim(name, "'"), maxSheetNameLength)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return s
+}
+
+func writeZipEntry(zw *zip.Writer, name, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+func writeXLSXSheet(w io.Writer, names []string, orders []models.CoordInfo) error {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	sb.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	sb.WriteString(`<sheetData>`)
+
+	writeXLSXRow(&sb, 1, headerRow(names), nil, true)
+	for i, info := range orders {
+		writeXLSXRow(&sb, i+2, valueRow(names, i+1, info), names, false)
+	}
+
+	sb.WriteString(`</sheetData>`)
+	sb.WriteString(fmt.Sprintf(`<autoFilter ref="A1:%s%d"/>`, columnLetter(len(names)), len(orders)+1))
+	sb.WriteString(`</worksheet>`)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Estilos definidos en xlsxStyles: 1 encabezado en negrita, 2 coordenada con
+// siete decimales.
+const (
+	styleHeader     = 1
+	styleCoordinate = 2
+)
+
+func writeXLSXRow(sb *strings.Builder, rowNum int, values, names []string, header bool) {
+	fmt.Fprintf(sb, `<row r="%d">`, rowNum)
+	for i, value := range values {
+		ref := fmt.Sprintf("%s%d", columnLetter(i+1), rowNum)
+
+		if header {
+			fmt.Fprintf(sb, `<c r="%s" t="inlineStr" s="%d"><is><t>%s</t></is></c>`, ref, styleHeader, escapeXML(value))
+			continue
+		}
+
+		col := columns[names[i]]
+		// Una parada consolidada puede listar varios valores en una columna
+		// numérica; en ese caso se escribe como texto.
+		if _, err := strconv.ParseFloat(value, 64); col.numeric && err == nil {
+			style := 0
+			if names[i] == ColumnLat || names[i] == ColumnLon {
+				style = styleCoordinate
+			}
+			fmt.Fprintf(sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, value)
+			continue
+		}
+
+		fmt.Fprintf(sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(value))
+	}
+	sb.WriteString(`</row>`)
+}
+
+// columnLetter convierte un número de columna (desde 1) en su letra: 1 → A,
+// 27 → AA.
+func columnLetter(n int) string {
+	var letras []byte
+	for n > 0 {
+		n--
+		letras = append([]byte{byte('A' + n%26)}, letras...)
+		n /= 26
+	}
+	return string(letras)
+}
+
+func escapeXML(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+func xlsxContentTypes(sheetCount int) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func xlsxWorkbook(sheets []xlsxSheet) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&sb, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheet.name), i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+	return sb.String()
+}
+
+func xlsxWorkbookRels(sheetCount int) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheetCount+1)
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="0.0000000"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
@@ -0,0 +1,134 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+const earthRadiusKm = 6371.0
+
+// Point es una posición geográfica en grados decimales.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// HaversineKm devuelve la distancia en kilómetros entre dos puntos sobre la
+// superficie terrestre.
+func HaversineKm(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := toRadians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// ValidRange indica si la latitud y la longitud están dentro de los rangos
+// posibles.
+func ValidRange(p Point) bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// BBox es el rectángulo mínimo que contiene un conjunto de puntos.
+type BBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// Bounds devuelve el rectángulo que contiene los puntos.
+func Bounds(points []Point) BBox {
+	if len(points) == 0 {
+		return BBox{}
+	}
+	b := BBox{MinLat: points[0].Lat, MinLon: points[0].Lon, MaxLat: points[0].Lat, MaxLon: points[0].Lon}
+	for _, p := range points[1:] {
+		b.MinLat = math.Min(b.MinLat, p.Lat)
+		b.MinLon = math.Min(b.MinLon, p.Lon)
+		b.MaxLat = math.Max(b.MaxLat, p.Lat)
+		b.MaxLon = math.Max(b.MaxLon, p.Lon)
+	}
+	return b
+}
+
+// Centroid devuelve el promedio de los puntos.
+func Centroid(points []Point) Point {
+	if len(points) == 0 {
+		return Point{}
+	}
+	var c Point
+	for _, p := range points {
+		c.Lat += p.Lat
+		c.Lon += p.Lon
+	}
+	c.Lat /= float64(len(points))
+	c.Lon /= float64(len(points))
+	return c
+}
+
+// ConvexHullAreaKm2 devuelve el área en km² de la envolvente convexa de los
+// puntos. Los puntos se proyectan en un plano local alrededor de su
+// centroide, lo que basta para las distancias de una ruta de reparto.
+func ConvexHullAreaKm2(points []Point) float64 {
+	if len(points) < 3 {
+		return 0
+	}
+
+	centro := Centroid(points)
+	escalaLon := math.Cos(toRadians(centro.Lat))
+	plano := make([][2]float64, len(points))
+	for i, p := range points {
+		plano[i] = [2]float64{
+			earthRadiusKm * toRadians(p.Lon-centro.Lon) * escalaLon,
+			earthRadiusKm * toRadians(p.Lat-centro.Lat),
+		}
+	}
+
+	hull := convexHull(plano)
+	area := 0.0
+	for i := range hull {
+		a, b := hull[i], hull[(i+1)%len(hull)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	return math.Abs(area) / 2
+}
+
+// convexHull aplica el algoritmo de cadena monótona y devuelve los vértices
+// de la envolvente en sentido antihorario.
+func convexHull(pts [][2]float64) [][2]float64 {
+	pts = append([][2]float64(nil), pts...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i][0] != pts[j][0] {
+			return pts[i][0] < pts[j][0]
+		}
+		return pts[i][1] < pts[j][1]
+	})
+
+	cross := func(o, a, b [2]float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+
+	var hull [][2]float64
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	inferior := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		for len(hull) >= inferior && cross(hull[len(hull)-2], hull[len(hull)-1], pts[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pts[i])
+	}
+	return hull[:len(hull)-1]
+}
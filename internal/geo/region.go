@@ -0,0 +1,179 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+// Polygon es un anillo exterior de puntos; no se consideran agujeros.
+type Polygon []Point
+
+// Region es un área formada por uno o más polígonos.
+type Region struct {
+	Name     string
+	Polygons []Polygon
+}
+
+func (r Region) Contains(p Point) bool {
+	for _, polygon := range r.Polygons {
+		if polygon.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains usa el algoritmo de ray casting sobre longitud y latitud.
+func (poly Polygon) Contains(p Point) bool {
+	dentro := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			dentro = !dentro
+		}
+	}
+	return dentro
+}
+
+// builtinRegions son polígonos aproximados, con margen, suficientes para
+// detectar geocodificaciones claramente erróneas.
+var builtinRegions = map[string]Region{
+	"chile": {
+		Name: "chile",
+		Polygons: []Polygon{{
+			{Lat: -17.3, Lon: -71.0}, {Lat: -17.3, Lon: -69.2}, {Lat: -19.0, Lon: -68.1},
+			{Lat: -21.0, Lon: -67.8}, {Lat: -22.5, Lon: -66.9}, {Lat: -24.0, Lon: -67.0},
+			{Lat: -26.0, Lon: -68.0}, {Lat: -28.0, Lon: -68.6}, {Lat: -30.0, Lon: -69.6},
+			{Lat: -32.0, Lon: -69.7}, {Lat: -33.0, Lon: -69.5}, {Lat: -35.0, Lon: -70.0},
+			{Lat: -37.0, Lon: -70.6}, {Lat: -39.0, Lon: -70.5}, {Lat: -41.0, Lon: -71.4},
+			{Lat: -44.0, Lon: -71.2}, {Lat: -46.0, Lon: -71.3}, {Lat: -48.0, Lon: -71.9},
+			{Lat: -50.0, Lon: -72.9}, {Lat: -51.9, Lon: -71.8}, {Lat: -52.0, Lon: -68.1},
+			{Lat: -54.0, Lon: -68.3}, {Lat: -56.2, Lon: -66.8}, {Lat: -56.2, Lon: -76.0},
+			{Lat: -45.0, Lon: -76.0}, {Lat: -40.0, Lon: -74.5}, {Lat: -35.0, Lon: -73.5},
+			{Lat: -30.0, Lon: -72.5}, {Lat: -25.0, Lon: -71.5}, {Lat: -20.0, Lon: -71.0},
+		}},
+	},
+	"rm": {
+		Name: "rm",
+		Polygons: []Polygon{{
+			{Lat: -32.9, Lon: -71.8}, {Lat: -32.9, Lon: -69.7},
+			{Lat: -34.3, Lon: -69.7}, {Lat: -34.3, Lon: -71.8},
+		}},
+	},
+}
+
+const DefaultRegion = "chile"
+
+func BuiltinRegionNames() []string {
+	var names []string
+	for name := range builtinRegions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadRegion devuelve una región predefinida por nombre o, si el valor es la
+// ruta de un archivo, la lee como GeoJSON (Polygon, MultiPolygon, Feature o
+// FeatureCollection).
+func LoadRegion(value string) (Region, error) {
+	if value == "" {
+		value = DefaultRegion
+	}
+
+	if region, ok := builtinRegions[strings.ToLower(value)]; ok {
+		return region, nil
+	}
+
+	contenido, err := os.ReadFile(value)
+	if err != nil {
+		return Region{}, fmt.Errorf("%s (%s): %w", value, strings.Join(BuiltinRegionNames(), ", "), err)
+	}
+
+	polygons, err := parseGeoJSONPolygons(contenido)
+	if err != nil {
+		return Region{}, fmt.Errorf("%s: %w", value, err)
+	}
+	if len(polygons) == 0 {
+		return Region{}, errors.New(i18n.T("validate.no_polygons", value))
+	}
+
+	return Region{Name: value, Polygons: polygons}, nil
+}
+
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+	Features    []geoJSONObject `json:"features"`
+}
+
+func parseGeoJSONPolygons(data []byte) ([]Polygon, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return collectPolygons(obj)
+}
+
+func collectPolygons(obj geoJSONObject) ([]Polygon, error) {
+	switch obj.Type {
+	case "FeatureCollection":
+		var polygons []Polygon
+		for _, feature := range obj.Features {
+			p, err := collectPolygons(feature)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, p...)
+		}
+		return polygons, nil
+
+	case "Feature":
+		if obj.Geometry == nil {
+			return nil, nil
+		}
+		return collectPolygons(*obj.Geometry)
+
+	case "Polygon":
+		var rings [][][2]float64
+		if err := json.Unmarshal(obj.Coordinates, &rings); err != nil {
+			return nil, err
+		}
+		if len(rings) == 0 {
+			return nil, nil
+		}
+		return []Polygon{ringToPolygon(rings[0])}, nil
+
+	case "MultiPolygon":
+		var multi [][][][2]float64
+		if err := json.Unmarshal(obj.Coordinates, &multi); err != nil {
+			return nil, err
+		}
+		var polygons []Polygon
+		for _, rings := range multi {
+			if len(rings) > 0 {
+				polygons = append(polygons, ringToPolygon(rings[0]))
+			}
+		}
+		return polygons, nil
+	}
+
+	return nil, nil
+}
+
+// ringToPolygon convierte un anillo GeoJSON ([lon, lat]) en un Polygon.
+func ringToPolygon(ring [][2]float64) Polygon {
+	polygon := make(Polygon, 0, len(ring))
+	for _, c := range ring {
+		polygon = append(polygon, Point{Lat: c[1], Lon: c[0]})
+	}
+	return polygon
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/importer"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+)
+
+// CompareMapSuffix se agrega al nombre del plan para el mapa de la
+// comparación cuando no se indica otro.
+const CompareMapSuffix = "_comparacion.html"
+
+// CompareOptions reúne las opciones de la comparación de secuencias.
+type CompareOptions struct {
+	Input importer.Options
+	// MapFile es el mapa HTML a generar; vacío para usar el nombre del plan
+	// con CompareMapSuffix.
+	MapFile string
+	// Offline incluye Leaflet en el mapa para abrirlo sin conexión.
+	Offline bool
+	JSON    bool
+}
+
+// CompareResult es el resultado de la comparación, que se imprime en stdout
+// con la opción --json.
+type CompareResult struct {
+	Planned     string `json:"planned"`
+	Alternative string `json:"alternative"`
+	coords.SequenceComparison
+	Map string `json:"map"`
+}
+
+// CompararSecuencias compara la secuencia planificada de las paradas con una
+// alternativa (optimizada o editada a mano) y genera un mapa con las dos rutas
+// superpuestas. Los archivos se leen como en GenerarMapaHTML.
+func CompararSecuencias(plan, alternativa string, opts CompareOptions) error {
+	output.ClearScreen()
+	output.Title(i18n.T("compare.title"))
+
+	planificadas, err := readStops(plan, opts.Input)
+	if err != nil {
+		return err
+	}
+	alternativas, err := readStops(alternativa, opts.Input)
+	if err != nil {
+		return err
+	}
+
+	result := CompareResult{
+		Planned:            plan,
+		Alternative:        alternativa,
+		SequenceComparison: coords.CompareSequences(planificadas, alternativas),
+	}
+	slog.Info("secuencias comparadas", "planned", plan, "alternative", alternativa, "matched_by", result.MatchedBy, "planned_km", result.PlannedKm, "alternative_km", result.AlternativeKm, "changed", result.Changed)
+
+	output.Println("\n" + i18n.T("compare.summary", plan, len(planificadas), result.PlannedKm, alternativa, len(alternativas), result.AlternativeKm))
+	printSequenceComparison(result.SequenceComparison)
+
+	result.Map = opts.MapFile
+	if result.Map == "" {
+		result.Map = strings.TrimSuffix(plan, filepath.Ext(plan)) + CompareMapSuffix
+	}
+	if err := generateCompareMap(result, planificadas, alternativas, opts.Offline); err != nil {
+		return err
+	}
+	output.Println("\n" + i18n.T("map.file_created", result.Map))
+
+	if opts.JSON {
+		if err := output.PrintJSON(result); err != nil {
+			return apperr.Wrap(apperr.KindIO, err)
+		}
+	}
+	return nil
+}
+
+func printSequenceComparison(c coords.SequenceComparison) {
+	if c.PlannedKm > 0 {
+		diferencia := c.AlternativeKm - c.PlannedKm
+		output.Println(i18n.T("compare.difference", diferencia, diferencia/c.PlannedKm*100))
+	}
+	if c.MatchedBy == "coordinates" {
+		output.Warning(i18n.T("compare.by_coordinates"))
+	}
+
+	if c.Changed == 0 && c.OnlyPlanned == 0 && c.OnlyAlternative == 0 {
+		output.Success(i18n.T("compare.no_changes"))
+		return
+	}
+
+	if c.Changed > 0 {
+		output.Println("\n" + i18n.T("compare.changed", c.Changed))
+		for _, s := range c.Stops {
+			if s.Shift != 0 {
+				output.Println("  # " + i18n.T("compare.stop", stopLabel(s), s.Planned, s.Alternative))
+			}
+		}
+	}
+
+	if c.OnlyPlanned > 0 {
+		output.Println("\n" + i18n.T("compare.only_planned", c.OnlyPlanned))
+		for _, s := range c.Stops {
+			if s.Alternative == 0 {
+				output.Println("  - " + stopLabel(s))
+			}
+		}
+	}
+
+	if c.OnlyAlternative > 0 {
+		output.Println("\n" + i18n.T("compare.only_alternative", c.OnlyAlternative))
+		for _, s := range c.Stops {
+			if s.Planned == 0 {
+				output.Println("  + " + stopLabel(s))
+			}
+		}
+	}
+}
+
+// stopLabel identifica una parada por su orden o, si no la tiene, por sus
+// coordenadas, junto a su pallet.
+func stopLabel(s coords.ComparedStop) string {
+	label := s.OrderID
+	if label == "" {
+		label = fmt.Sprintf("(%.6f, %.6f)", s.Lat, s.Lon)
+	}
+	if s.PalletCode != "" {
+		label += " (pallet " + s.PalletCode + ")"
+	}
+	return label
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// routeLines separa las paradas en una línea por pallet, como pares
+// [lat, lon] en el orden recibido.
+func routeLines(paradas []models.Coordenada) [][][2]float64 {
+	lineas := [][][2]float64{}
+	for i, p := range paradas {
+		if i == 0 || p.Pallet != paradas[i-1].Pallet {
+			lineas = append(lineas, nil)
+		}
+		lineas[len(lineas)-1] = append(lineas[len(lineas)-1], [2]float64{p.Lat, p.Lon})
+	}
+	return lineas
+}
+
+func generateCompareMap(result CompareResult, planned, alternative []models.Coordenada, offline bool) error {
+	leaflet, err := loadLeaflet(offline)
+	if err != nil {
+		return err
+	}
+
+	htmlTemplate := `<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{t "compare.html.title"}}</title>
+    {{template "leaflet-css" .}}
+    <style>
+        body {
+            margin: 0;
+            padding: 0;
+            font-family: Arial, sans-serif;
+        }
+        #map {
+            height: 600px;
+            width: 100%;
+        }
+        .info-panel {
+            padding: 10px;
+            background: white;
+            border-radius: 5px;
+            box-shadow: 0 0 15px rgba(0,0,0,0.2);
+            margin-bottom: 10px;
+        }
+        .layout {
+            display: flex;
+            gap: 10px;
+        }
+        .map-container {
+            flex: 1;
+            min-width: 0;
+        }
+        .sidebar {
+            width: 340px;
+            height: 600px;
+            display: flex;
+            flex-direction: column;
+            border: 1px solid #ddd;
+            border-radius: 4px;
+            font-size: 13px;
+        }
+        .routes {
+            padding: 8px;
+            border-bottom: 1px solid #ddd;
+        }
+        .routes label {
+            display: block;
+            margin-bottom: 4px;
+        }
+        .swatch {
+            display: inline-block;
+            width: 16px;
+            height: 4px;
+            margin: 0 4px 3px;
+        }
+        .stops {
+            overflow-y: auto;
+            flex: 1;
+        }
+        table {
+            width: 100%;
+            border-collapse: collapse;
+        }
+        th, td {
+            padding: 4px 6px;
+            border-top: 1px solid #eee;
+            text-align: left;
+        }
+        tbody tr {
+            cursor: pointer;
+        }
+        tbody tr:hover {
+            background-color: #f0f7ff;
+        }
+        .changed {
+            color: #e67e22;
+            font-weight: bold;
+        }
+    </style>
+</head>
+<body>
+    <div class="info-panel">
+        <h1>{{t "compare.html.title"}}</h1>
+    </div>
+
+    <div class="layout">
+        <div class="sidebar">
+            <div class="routes">
+                <label><input type="checkbox" id="show-planned" checked><span class="swatch" style="background-color: #2980b9;"></span><b>{{t "compare.html.planned"}}</b> ({{.PlannedName}}): <span id="planned-km"></span></label>
+                <label><input type="checkbox" id="show-alternative" checked><span class="swatch" style="background-color: #8e44ad;"></span><b>{{t "compare.html.alternative"}}</b> ({{.AlternativeName}}): <span id="alternative-km"></span></label>
+                <div id="difference"></div>
+                <label><input type="checkbox" id="only-changed"> {{t "compare.html.only_changed"}}</label>
+            </div>
+            <div class="stops">
+                <table>
+                    <thead>
+                        <tr><th>{{t "compare.html.stop"}}</th><th>{{t "compare.html.planned"}}</th><th>{{t "compare.html.alternative"}}</th><th>&Delta;</th></tr>
+                    </thead>
+                    <tbody id="stop-rows"></tbody>
+                </table>
+            </div>
+        </div>
+        <div class="map-container">
+            {{template "leaflet-fallback" .}}
+            <div id="map"></div>
+        </div>
+    </div>
+
+    {{template "leaflet-js" .}}
+
+    <script>
+        const map = L.map('map');
+
+        watchTiles(L.tileLayer('https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png', {
+            attribution: '&copy; <a href="https://www.openstreetmap.org/copyright">OpenStreetMap</a> contributors'
+        })).addTo(map);
+
+        const stops = {{.Stops}};
+        const plannedKm = {{.PlannedKm}};
+        const alternativeKm = {{.AlternativeKm}};
+        const colors = {
+            planned: '#2980b9',
+            alternative: '#8e44ad',
+            changed: '#e67e22',
+            unchanged: '#7f8c8d'
+        };
+
+        // Una línea por pallet para cada secuencia; la alternativa punteada
+        const plannedLayer = L.layerGroup().addTo(map);
+        {{.PlannedRoutes}}.forEach(route => {
+            L.polyline(route, {color: colors.planned, weight: 3, opacity: 0.8}).addTo(plannedLayer);
+        });
+        const alternativeLayer = L.layerGroup().addTo(map);
+        {{.AlternativeRoutes}}.forEach(route => {
+            L.polyline(route, {color: colors.alternative, weight: 3, opacity: 0.8, dashArray: '6 6'}).addTo(alternativeLayer);
+        });
+
+        function escapeHTML(value) {
+            return String(value).replace(/[&<>"']/g, c => ({'&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;'})[c]);
+        }
+
+        function position(value) {
+            return value ? '' + value : '&ndash;';
+        }
+
+        // Posición en el plan y en la alternativa, o solo la que exista
+        function stopNumber(s) {
+            if (!s.alternative) {
+                return s.planned + '&rarr;&ndash;';
+            }
+            if (!s.planned) {
+                return '&ndash;&rarr;' + s.alternative;
+            }
+            return s.shift ? s.planned + '&rarr;' + s.alternative : '' + s.planned;
+        }
+
+        function stopColor(s) {
+            if (!s.alternative) {
+                return colors.planned;
+            }
+            if (!s.planned) {
+                return colors.alternative;
+            }
+            return s.shift ? colors.changed : colors.unchanged;
+        }
+
+        const group = L.featureGroup().addTo(map);
+        const rows = document.getElementById('stop-rows');
+        const entries = stops.map(s => {
+            const label = s.order_id || (s.lat.toFixed(6) + ', ' + s.lon.toFixed(6));
+            const icon = L.divIcon({
+                html: '<div style="background-color: ' + stopColor(s) + '; color: white; border-radius: 12px; min-width: 24px; height: 24px; padding: 0 4px; display: flex; align-items: center; justify-content: center; font-weight: bold; font-size: 11px; box-shadow: 0 0 3px rgba(0,0,0,0.5);">' + stopNumber(s) + '</div>',
+                className: '',
+                iconSize: null,
+                iconAnchor: [12, 12]
+            });
+
+            let popup = '<b>' + escapeHTML(label) + '</b>';
+            if (s.pallet) {
+                popup += '<br>Pallet: ' + escapeHTML(s.pallet);
+            }
+            popup += '<br>' + {{t "compare.html.planned"}} + ': ' + position(s.planned);
+            popup += '<br>' + {{t "compare.html.alternative"}} + ': ' + position(s.alternative);
+            if (s.shift) {
+                popup += '<br>' + {{t "compare.html.shift"}} + ': ' + (s.shift > 0 ? '+' : '') + s.shift;
+            }
+            const marker = L.marker([s.lat, s.lon], {icon: icon}).bindPopup(popup).addTo(group);
+
+            // Fila de la tabla: al elegirla se centra el mapa en la parada
+            const row = document.createElement('tr');
+            const changed = s.shift !== 0 || !s.planned || !s.alternative;
+            if (changed) {
+                row.className = 'changed';
+            }
+            row.innerHTML = '<td>' + escapeHTML(label) + (s.pallet ? '<br><small>' + escapeHTML(s.pallet) + '</small>' : '') + '</td><td>' + position(s.planned) + '</td><td>' + position(s.alternative) + '</td><td>' + (s.shift ? (s.shift > 0 ? '+' : '') + s.shift : '') + '</td>';
+            row.addEventListener('click', () => {
+                map.setView(marker.getLatLng(), Math.max(map.getZoom(), 16));
+                marker.openPopup();
+            });
+            rows.appendChild(row);
+            return {row: row, changed: changed};
+        });
+
+        document.getElementById('planned-km').textContent = plannedKm.toFixed(1) + ' km';
+        document.getElementById('alternative-km').textContent = alternativeKm.toFixed(1) + ' km';
+        if (plannedKm > 0) {
+            const diferencia = alternativeKm - plannedKm;
+            const signo = diferencia > 0 ? '+' : '';
+            document.getElementById('difference').textContent = {{t "compare.html.difference"}} + ': ' + signo + diferencia.toFixed(1) + ' km (' + signo + (diferencia / plannedKm * 100).toFixed(1) + '%)';
+        }
+
+        function showLayer(layer, visible) {
+            if (visible) {
+                map.addLayer(layer);
+            } else {
+                map.removeLayer(layer);
+            }
+        }
+        document.getElementById('show-planned').addEventListener('change', e => showLayer(plannedLayer, e.target.checked));
+        document.getElementById('show-alternative').addEventListener('change', e => showLayer(alternativeLayer, e.target.checked));
+        document.getElementById('only-changed').addEventListener('change', e => {
+            entries.forEach(entry => {
+                entry.row.style.display = e.target.checked && !entry.changed ? 'none' : '';
+            });
+        });
+
+        if (stops.length > 0) {
+            map.fitBounds(group.getBounds());
+        } else {
+            map.setView([-33.45, -70.65], 11);
+        }
+    </script>
+</body>
+</html>`
+
+	tmpl, err := parseMapTemplate("compare", htmlTemplate, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("map.err_template"), err)
+	}
+
+	archivoHTML, err := os.Create(result.Map)
+	if err != nil {
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_create"), err)
+	}
+	defer archivoHTML.Close()
+
+	datos := struct {
+		CompareResult
+		PlannedName       string
+		AlternativeName   string
+		PlannedRoutes     [][][2]float64
+		AlternativeRoutes [][][2]float64
+		leafletPage
+	}{
+		CompareResult:     result,
+		PlannedName:       filepath.Base(result.Planned),
+		AlternativeName:   filepath.Base(result.Alternative),
+		PlannedRoutes:     routeLines(planned),
+		AlternativeRoutes: routeLines(alternative),
+		leafletPage:       leaflet,
+	}
+
+	if err := tmpl.Execute(archivoHTML, datos); err != nil {
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_render"), err)
+	}
+
+	slog.Info("mapa de comparación generado", "file", result.Map, "stops", len(result.Stops))
+	return nil
+}
@@ -5,106 +5,231 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Cait-dev/alas-tools-cli/internal/api"
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
 	"github.com/Cait-dev/alas-tools-cli/internal/config"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/export"
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
 	"github.com/Cait-dev/alas-tools-cli/internal/models"
+	"github.com/Cait-dev/alas-tools-cli/internal/outfile"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+	"github.com/Cait-dev/alas-tools-cli/internal/pallet"
 )
 
-func ObtenerCoordenadas() {
-	fmt.Print("\033[H\033[2J")
+// CoordsOptions reúne las opciones de la extracción de coordenadas que no se
+// preguntan de forma interactiva.
+type CoordsOptions struct {
+	Export export.Options
+	Region geo.Region
+	// OutlierMethod es uno de los métodos de coords.OutlierMethods.
+	OutlierMethod string
+	// ConsolidateRadius es el radio en metros para agrupar órdenes cercanas
+	// en una sola parada; 0 desactiva la consolidación.
+	ConsolidateRadius float64
+	// Output indica el directorio y el nombre de los archivos generados.
+	Output outfile.Options
+	// Map genera el mapa HTML sin preguntar.
+	Map bool
+	// MapOffline incluye Leaflet en el mapa para abrirlo sin conexión.
+	MapOffline bool
+	JSON       bool
+
+	// baseNames fija los nombres de los archivos, ya resueltos, para que
+	// coords watch reescriba siempre los mismos. La clave son los pallets
+	// separados por comas.
+	baseNames map[string]string
+	// retry limita la escritura a los pasos que fallaron en el ciclo anterior
+	// de coords watch; nil escribe todos.
+	retry map[string]bool
+}
 
-	verde := "\033[32m"
-	reset := "\033[0m"
-	titulo := verde + "[Obtener Coordenadas]" + reset
+// CoordsResult es el resumen de una extracción, que se imprime en stdout con
+// la opción --json.
+type CoordsResult struct {
+	Pallets            []string               `json:"pallets"`
+	TotalOrders        int                    `json:"total_orders"`
+	Coordinates        []models.CoordInfo     `json:"coordinates"`
+	MissingGeolocation []models.MissingOrder  `json:"missing_geolocation"`
+	MissingReport      string                 `json:"missing_report,omitempty"`
+	Region             string                 `json:"region"`
+	Validation         map[string]int         `json:"validation"`
+	Outliers           []coords.Outlier       `json:"outliers"`
+	SequenceIssues     []models.SequenceIssue `json:"sequence_issues"`
+	SequenceReport     string                 `json:"sequence_report,omitempty"`
+	RouteStats         []coords.RouteStats    `json:"route_stats"`
+	StatsReport        string                 `json:"stats_report,omitempty"`
+	Stops              int                    `json:"stops"`
+	ConsolidatedStops  int                    `json:"consolidated_stops"`
+	PalletFiles        []models.PalletFiles   `json:"pallet_files,omitempty"`
+	Index              string                 `json:"index,omitempty"`
+	Files              []string               `json:"files"`
+
+	// failed son los pasos de escritura que fallaron sin detener la
+	// extracción, para que coords watch los reintente.
+	failed []string
+}
 
-	fmt.Println("\n" + titulo)
-	fmt.Println("\nEsta herramienta extrae coordenadas de las órdenes asociadas a un pallet.")
+// Pasos de escritura que coords watch reintenta por separado. Los archivos
+// de un formato de exportación usan stepExport seguido del formato.
+const (
+	stepClean   = "clean"
+	stepStats   = "stats"
+	stepExport  = "export:"
+	stepPallets = "pallets"
+	stepMap     = "map"
+)
 
-	reader := bufio.NewReader(os.Stdin)
+// writes indica si se debe escribir el paso; solo se omite al reintentar
+// otros pasos.
+func (opts CoordsOptions) writes(step string) bool {
+	return opts.retry == nil || opts.retry[step]
+}
 
-	fmt.Print("\nIngrese el código de pallet (ej. pl202505danl001) o varios separados por comas: ")
-	palletInput, _ := reader.ReadString('\n')
-	palletInput = strings.TrimSpace(palletInput)
+// DefaultCoordsOptions construye las opciones a partir de la configuración.
+func DefaultCoordsOptions() (CoordsOptions, error) {
+	formats, err := export.ParseFormats(config.ExportFormats())
+	if err != nil {
+		return CoordsOptions{}, apperr.New(apperr.KindUsage, i18n.T("export.unsupported", err))
+	}
 
-	palletCodes := strings.Split(palletInput, ",")
-	for i, code := range palletCodes {
-		palletCodes[i] = strings.TrimSpace(code)
+	columns, err := export.ParseColumns(config.ExportColumns())
+	if err != nil {
+		return CoordsOptions{}, apperr.New(apperr.KindUsage, i18n.T("export.unsupported_column", err))
 	}
 
-	var validPalletCodes []string
-	for _, code := range palletCodes {
-		if code != "" {
-			validPalletCodes = append(validPalletCodes, code)
+	region, err := LoadRegion(config.Region())
+	if err != nil {
+		return CoordsOptions{}, err
+	}
+
+	outlierMethod, err := coords.ParseOutlierMethod(config.OutlierMethod())
+	if err != nil {
+		return CoordsOptions{}, apperr.New(apperr.KindUsage, i18n.T("outliers.unsupported", err))
+	}
+
+	radius := coords.DefaultConsolidateRadiusM
+	if value := config.ConsolidateRadius(); value != "" {
+		if radius, err = ParseConsolidateRadius(value); err != nil {
+			return CoordsOptions{}, err
 		}
 	}
 
-	if len(validPalletCodes) == 0 {
-		fmt.Println(verde + "\n[ERROR]" + reset + " Debe ingresar al menos un código de pallet válido.")
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+	policy, err := outfile.ParsePolicy(config.Overwrite())
+	if err != nil {
+		return CoordsOptions{}, apperr.New(apperr.KindUsage, i18n.T("outfile.unsupported_policy", err))
 	}
 
-	fmt.Printf("\nConsultando API para %d pallet(s): %s...\n", len(validPalletCodes), strings.Join(validPalletCodes, ", "))
+	template, err := outfile.ParseTemplate(config.OutputTemplate())
+	if err != nil {
+		return CoordsOptions{}, apperr.New(apperr.KindUsage, i18n.T("outfile.invalid_template", err))
+	}
 
-	apiUser, apiPassword := config.GetAPICredentials()
-	client := api.NewClient(apiUser, apiPassword)
+	return CoordsOptions{
+		Export: export.Options{
+			Formats: formats,
+			Route:   config.ExportRoute(),
+			Columns: columns,
+		},
+		Region:            region,
+		OutlierMethod:     outlierMethod,
+		ConsolidateRadius: radius,
+		Output: outfile.Options{
+			Dir:      config.OutputDir(),
+			Template: template,
+			Daily:    config.OutputDaily(),
+			Policy:   policy,
+			Profile:  config.Profile(),
+		},
+		MapOffline: config.MapOffline(),
+	}, nil
+}
 
-	sourceFields := []string{"vehicle_location", "destination.geo_location"}
+// ParseConsolidateRadius interpreta el radio de consolidación en metros.
+func ParseConsolidateRadius(value string) (float64, error) {
+	radius, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || radius < 0 {
+		return 0, apperr.New(apperr.KindUsage, i18n.T("consolidate.invalid_radius", value))
+	}
+	return radius, nil
+}
 
-	responseBody, err := client.SearchDeliveryOrders(validPalletCodes, 0, 1, sourceFields)
+// LoadRegion carga la región de validación, devolviendo un error de uso si no
+// existe.
+func LoadRegion(value string) (geo.Region, error) {
+	region, err := geo.LoadRegion(value)
 	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		return geo.Region{}, apperr.New(apperr.KindUsage, i18n.T("validate.region_error", err))
 	}
+	return region, nil
+}
 
-	var initialResponseData struct {
-		Total int `json:"total"`
+func ObtenerCoordenadas(palletInput string, opts CoordsOptions) error {
+	output.ClearScreen()
+
+	output.Title(i18n.T("coords.title"))
+	output.Println("\n" + i18n.T("coords.intro"))
+
+	if palletInput == "" && output.IsInteractive() {
+		reader := bufio.NewReader(os.Stdin)
+		output.Print("\n" + i18n.T("coords.prompt"))
+		palletInput, _ = reader.ReadString('\n')
 	}
+	palletInput = strings.TrimSpace(palletInput)
 
-	err = json.Unmarshal(responseBody, &initialResponseData)
+	validPalletCodes, err := pallet.ParseList(palletInput)
 	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " Error al procesar la respuesta: " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		slog.Warn("códigos de pallet inválidos", "input", palletInput, "error", err)
+		return apperr.New(apperr.KindUsage, i18n.T("pallet.invalid", err))
 	}
 
-	totalItems := initialResponseData.Total
-	if totalItems == 0 {
-		fmt.Println(verde + "\n[AVISO]" + reset + " No se encontraron órdenes para los pallets proporcionados.")
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+	if len(validPalletCodes) == 0 {
+		return apperr.New(apperr.KindUsage, i18n.T("coords.no_pallets"))
 	}
 
-	fmt.Printf("Se encontraron un total de %d órdenes. Obteniendo coordenadas...\n", totalItems)
+	output.Println("\n" + i18n.T("coords.querying", len(validPalletCodes), strings.Join(validPalletCodes, ", ")))
 
-	responseBody, err = client.SearchDeliveryOrders(validPalletCodes, 0, totalItems, sourceFields)
+	responseData, err := fetchDeliveryOrders(validPalletCodes)
 	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		return err
 	}
 
-	var responseData models.DeliveryOrderResponse
-	err = json.Unmarshal(responseBody, &responseData)
-	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " Error al procesar la respuesta: " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+	_, err = procesarOrdenes(validPalletCodes, responseData, opts)
+	return err
+}
+
+// procesarOrdenes valida las órdenes obtenidas de la API y escribe los
+// archivos de coordenadas, reportes y exportaciones. Devuelve el resumen de la
+// extracción junto con el error, si lo hubo.
+func procesarOrdenes(validPalletCodes []string, responseData models.DeliveryOrderResponse, opts CoordsOptions) (*CoordsResult, error) {
+	result := &CoordsResult{
+		Pallets:            validPalletCodes,
+		TotalOrders:        len(responseData.Items),
+		Coordinates:        []models.CoordInfo{},
+		MissingGeolocation: []models.MissingOrder{},
+		Outliers:           []coords.Outlier{},
+		SequenceIssues:     []models.SequenceIssue{},
+		Files:              []string{},
 	}
 
 	var coordInfos []models.CoordInfo
+	var secuencia []coords.SequenceOrder
 	for i, item := range responseData.Items {
+		secuencia = append(secuencia, coords.SequenceOrder{
+			OrderID:         item.ID.String(),
+			PalletCode:      item.PalletCode,
+			VehicleLocation: item.VehicleLocation,
+		})
+
 		lat := item.Destination.GeoLocation.Lat
 		lon := item.Destination.GeoLocation.Lon
 		vehicleLoc := item.VehicleLocation
@@ -115,76 +240,617 @@ func ObtenerCoordenadas() {
 				Lon:             lon,
 				VehicleLocation: vehicleLoc,
 				Index:           i,
+				OrderID:         item.ID.String(),
+				PalletCode:      item.PalletCode,
+				TrackingCode:    item.TrackingCode,
+				CustomerName:    item.Destination.ContactName,
+				Address:         item.Destination.Address,
+				Commune:         item.Destination.Commune,
+			})
+		} else {
+			result.MissingGeolocation = append(result.MissingGeolocation, models.MissingOrder{
+				OrderID:         item.ID.String(),
+				PalletCode:      item.PalletCode,
+				Address:         item.Destination.Address,
+				Commune:         item.Destination.Commune,
+				VehicleLocation: vehicleLoc,
 			})
 		}
 	}
 
+	slog.Info("coordenadas extraídas", "pallets", validPalletCodes, "orders", len(responseData.Items), "with_coordinates", len(coordInfos), "missing_geolocation", len(result.MissingGeolocation))
+
+	result.Region = opts.Region.Name
+	result.Validation = coords.Validate(coordInfos, opts.Region)
+	if outliers := coords.DetectOutliers(coordInfos, opts.OutlierMethod); len(outliers) > 0 {
+		result.Outliers = outliers
+		result.Validation[coords.FlagOutlier] = len(outliers)
+	}
+	reportValidation(result.Validation)
+	reportOutliers(result.Outliers)
+
+	// Todos los nombres se resuelven antes de escribir el primer archivo
+	if opts.baseNames == nil {
+		var err error
+		opts.baseNames, err = resolveBaseNames(outputGroups(validPalletCodes, coordInfos), opts.Output)
+		if err != nil {
+			return result, err
+		}
+	}
+	baseName, err := opts.outputBase(validPalletCodes)
+	if err != nil {
+		return result, err
+	}
+
+	// Al reintentar, los reportes y el historial ya se guardaron
+	if opts.retry == nil {
+		if err := saveMissingReport(baseName, result); err != nil {
+			return result, err
+		}
+	}
+
+	if issues := coords.CheckSequence(secuencia); len(issues) > 0 {
+		result.SequenceIssues = issues
+		if opts.retry == nil {
+			if err := saveSequenceReport(baseName, result); err != nil {
+				return result, err
+			}
+		}
+	}
+
 	if len(coordInfos) == 0 {
-		fmt.Println(verde + "\n[AVISO]" + reset + " No se encontraron coordenadas válidas para los pallets proporcionados.")
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		if opts.JSON {
+			if err := output.PrintJSON(result); err != nil {
+				return result, apperr.Wrap(apperr.KindIO, err)
+			}
+		}
+		return result, apperr.New(apperr.KindNotFound, i18n.T("coords.no_valid"))
 	}
 
-	sort.Slice(coordInfos, func(i, j int) bool {
+	if opts.retry == nil {
+		if err := config.RecordPallets(validPalletCodes); err != nil {
+			slog.Warn("no se pudo guardar el historial de pallets", "error", err)
+			output.Warning(i18n.T("coords.history_error", err))
+		}
+		if opts.Output.Profile != "" {
+			if err := config.RecordProfile(opts.Output.Profile); err != nil {
+				slog.Warn("no se pudo guardar el historial de perfiles", "error", err)
+				output.Warning(i18n.T("coords.profile_error", err))
+			}
+		}
+	}
+
+	// Cada pallet conserva su propia secuencia, en el orden en que se pidieron
+	orden := map[string]int{}
+	for i, code := range validPalletCodes {
+		orden[code] = i
+	}
+	sort.SliceStable(coordInfos, func(i, j int) bool {
+		pi, pj := palletRank(orden, coordInfos[i].PalletCode), palletRank(orden, coordInfos[j].PalletCode)
+		if pi != pj {
+			return pi < pj
+		}
+		if pi == len(orden) && coordInfos[i].PalletCode != coordInfos[j].PalletCode {
+			return coordInfos[i].PalletCode < coordInfos[j].PalletCode
+		}
 		return coordInfos[i].VehicleLocation < coordInfos[j].VehicleLocation
 	})
 
-	processAndSaveCoordinates(coordInfos, validPalletCodes)
+	paradas := coords.Consolidate(coordInfos, opts.ConsolidateRadius)
+	result.Stops = len(paradas)
+	result.ConsolidatedStops = coords.ConsolidatedCount(paradas)
+	if result.ConsolidatedStops > 0 {
+		slog.Info("paradas consolidadas", "orders", len(coordInfos), "stops", len(paradas), "consolidated", result.ConsolidatedStops, "radius_m", opts.ConsolidateRadius)
+		output.Println(i18n.T("consolidate.summary", len(coordInfos), len(paradas), result.ConsolidatedStops, opts.ConsolidateRadius))
+	}
+
+	return result, processAndSaveCoordinates(paradas, baseName, result, opts)
 }
 
-func processAndSaveCoordinates(coordInfos []models.CoordInfo, palletCodes []string) {
-	verde := "\033[32m"
-	reset := "\033[0m"
-	reader := bufio.NewReader(os.Stdin)
+// palletRank devuelve la posición del pallet en la consulta; los pallets que
+// no se pidieron van al final.
+func palletRank(orden map[string]int, pallet string) int {
+	if i, ok := orden[pallet]; ok {
+		return i
+	}
+	return len(orden)
+}
 
+// fetchDeliveryOrders consulta a la API todas las órdenes de los pallets.
+func fetchDeliveryOrders(palletCodes []string) (models.DeliveryOrderResponse, error) {
+	apiUser, apiPassword := config.GetAPICredentials()
+	client := api.NewClient(apiUser, apiPassword)
+
+	sourceFields := []string{"id", "pallet_code", "tracking_code", "vehicle_location", "destination.address", "destination.commune", "destination.contact_name", "destination.geo_location"}
+
+	responseBody, err := client.SearchDeliveryOrders(palletCodes, 0, 1, sourceFields)
+	if err != nil {
+		return models.DeliveryOrderResponse{}, err
+	}
+
+	var initialResponseData struct {
+		Total int `json:"total"`
+	}
+
+	err = json.Unmarshal(responseBody, &initialResponseData)
+	if err != nil {
+		return models.DeliveryOrderResponse{}, apperr.New(apperr.KindNetwork, i18n.T("coords.parse_error", err))
+	}
+
+	totalItems := initialResponseData.Total
+	if totalItems == 0 {
+		return models.DeliveryOrderResponse{}, apperr.New(apperr.KindNotFound, i18n.T("coords.no_orders"))
+	}
+
+	slog.Info("órdenes encontradas", "pallets", palletCodes, "total", totalItems)
+	output.Println(i18n.T("coords.found_orders", totalItems))
+
+	responseBody, err = client.SearchDeliveryOrders(palletCodes, 0, totalItems, sourceFields)
+	if err != nil {
+		return models.DeliveryOrderResponse{}, err
+	}
+
+	var responseData models.DeliveryOrderResponse
+	err = json.Unmarshal(responseBody, &responseData)
+	if err != nil {
+		return models.DeliveryOrderResponse{}, apperr.New(apperr.KindNetwork, i18n.T("coords.parse_error", err))
+	}
+
+	return responseData, nil
+}
+
+// reportValidation informa cuántas órdenes quedaron marcadas por cada motivo.
+func reportValidation(counts map[string]int) {
+	for _, flag := range coords.SortedFlags(counts) {
+		slog.Warn("coordenadas marcadas por validación", "flag", flag, "count", counts[flag])
+		output.Warning(i18n.T("validate.flagged", counts[flag], i18n.T("flag."+flag), flag))
+	}
+}
+
+// reportOutliers detalla las paradas atípicas con su distancia al centro del
+// pallet.
+func reportOutliers(outliers []coords.Outlier) {
+	for _, o := range outliers {
+		slog.Warn("parada atípica", "order_id", o.OrderID, "pallet", o.PalletCode, "vehicle_location", o.VehicleLocation, "distance_km", o.DistanceKm)
+		output.Println("  " + i18n.T("outliers.detail", o.OrderID, o.PalletCode, o.VehicleLocation, o.DistanceKm))
+	}
+}
+
+// outputSuffixes son los archivos que indican que una extracción anterior ya
+// usó un nombre.
+var outputSuffixes = []string{".txt", "_clean.txt", export.MissingReportSuffix, export.SequenceReportSuffix}
+
+// outputBase devuelve el nombre de los archivos de los pallets, usando el que
+// se resolvió antes de escribir si lo hay.
+func (opts CoordsOptions) outputBase(palletCodes []string) (string, error) {
+	if baseName, ok := opts.baseNames[strings.Join(palletCodes, ",")]; ok {
+		return baseName, nil
+	}
+	nombres, err := resolveBaseNames([][]string{palletCodes}, opts.Output)
+	if err != nil {
+		return "", err
+	}
+	return nombres[strings.Join(palletCodes, ",")], nil
+}
+
+// outputGroups devuelve los grupos de pallets que tienen archivos propios: los
+// pallets consultados juntos y, si son varios, cada pallet con órdenes.
+func outputGroups(palletCodes []string, coordInfos []models.CoordInfo) [][]string {
+	grupos := [][]string{palletCodes}
+	if len(palletCodes) < 2 {
+		return grupos
+	}
+	conOrdenes := map[string]bool{}
+	for _, info := range coordInfos {
+		conOrdenes[info.PalletCode] = true
+	}
+	for _, code := range palletCodes {
+		if conOrdenes[code] {
+			grupos = append(grupos, []string{code})
+		}
+	}
+	return grupos
+}
+
+// resolveBaseNames devuelve la ruta de los archivos de cada grupo de pallets,
+// sin extensión, y crea sus directorios. La clave son los pallets separados
+// por comas. Se resuelven todos antes de escribir, para que la política de
+// sobrescritura se aplique una sola vez al conjunto si alguno ya existe.
+func resolveBaseNames(grupos [][]string, opts outfile.Options) (map[string]string, error) {
+	ahora := time.Now()
+	nombres := map[string]string{}
+	var existentes []string
+	for _, grupo := range grupos {
+		baseName := opts.BaseName(grupo, ahora)
+		nombres[strings.Join(grupo, ",")] = baseName
+		if outfile.Exists(baseName, outputSuffixes) {
+			existentes = append(existentes, baseName)
+		}
+	}
+
+	if len(existentes) > 0 {
+		// Los mensajes terminan cada nombre con .*
+		lista := strings.Join(existentes, ".*, ")
+		policy := opts.Policy
+		if policy == outfile.PolicyPrompt {
+			policy = outfile.PolicySuffix
+			if output.Confirm("\n" + i18n.T("outfile.exists", lista)) {
+				policy = outfile.PolicyOverwrite
+			}
+		}
+
+		switch policy {
+		case outfile.PolicyFail:
+			return nil, apperr.New(apperr.KindIO, i18n.T("outfile.exists_fail", lista))
+		case outfile.PolicySuffix:
+			for _, grupo := range grupos {
+				clave := strings.Join(grupo, ",")
+				baseName := nombres[clave]
+				if !outfile.Exists(baseName, outputSuffixes) {
+					continue
+				}
+				nuevo := outfile.WithSuffix(baseName, outputSuffixes)
+				slog.Info("archivos existentes, se usa un nombre nuevo", "base", baseName, "new_base", nuevo)
+				output.Println(i18n.T("outfile.renamed", baseName, nuevo))
+				nombres[clave] = nuevo
+			}
+		default:
+			slog.Info("se sobrescriben los archivos existentes", "bases", existentes)
+		}
+	}
+
+	for _, baseName := range nombres {
+		if dir := filepath.Dir(baseName); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, apperr.New(apperr.KindIO, i18n.T("outfile.mkdir_error", dir, err))
+			}
+		}
+	}
+	return nombres, nil
+}
+
+// saveMissingReport escribe el reporte de órdenes sin geolocalización, si las
+// hay, y lo informa al usuario.
+func saveMissingReport(baseName string, result *CoordsResult) error {
+	if len(result.MissingGeolocation) == 0 {
+		return nil
+	}
+
+	reportFile := baseName + export.MissingReportSuffix
+	if err := export.WriteMissingReport(reportFile, result.MissingGeolocation); err != nil {
+		slog.Error("error al escribir el reporte de órdenes sin geolocalización", "file", reportFile, "error", err)
+		return apperr.New(apperr.KindIO, i18n.T("coords.write_error", err))
+	}
+
+	slog.Warn("órdenes sin geolocalización", "count", len(result.MissingGeolocation), "file", reportFile)
+	output.Warning(i18n.T("coords.missing_geolocation", len(result.MissingGeolocation), result.TotalOrders, reportFile))
+	result.MissingReport = reportFile
+	result.Files = append(result.Files, reportFile)
+	return nil
+}
+
+// saveSequenceReport informa los problemas de la secuencia de
+// vehicle_location agrupados por pallet y tipo, y los guarda en un reporte.
+func saveSequenceReport(baseName string, result *CoordsResult) error {
+	type grupo struct {
+		pallet, kind string
+	}
+	var grupos []grupo
+	valores := map[grupo][]string{}
+	ceros := map[grupo]int{}
+
+	for _, issue := range result.SequenceIssues {
+		g := grupo{issue.Pallet, issue.Kind}
+		if _, ok := valores[g]; !ok {
+			grupos = append(grupos, g)
+		}
+		switch issue.Kind {
+		case coords.SequenceZero:
+			ceros[g] += len(issue.OrderIDs)
+			valores[g] = nil
+		case coords.SequenceMultiplePallets:
+			valores[g] = append(valores[g], issue.OrderIDs...)
+		default:
+			valores[g] = append(valores[g], strconv.Itoa(issue.VehicleLocation))
+		}
+	}
+
+	for _, g := range grupos {
+		pallet := g.pallet
+		if pallet == "" {
+			pallet = i18n.T("sequence.all_pallets")
+		}
+		if g.kind == coords.SequenceZero {
+			output.Warning(i18n.T("sequence.zero", pallet, ceros[g]))
+		} else {
+			output.Warning(i18n.T("sequence."+g.kind, pallet, strings.Join(valores[g], ", ")))
+		}
+	}
+
+	reportFile := baseName + export.SequenceReportSuffix
+	if err := export.WriteSequenceReport(reportFile, result.SequenceIssues); err != nil {
+		slog.Error("error al escribir el reporte de vehicle_location", "file", reportFile, "error", err)
+		return apperr.New(apperr.KindIO, i18n.T("coords.write_error", err))
+	}
+
+	slog.Warn("problemas en la secuencia de vehicle_location", "count", len(result.SequenceIssues), "file", reportFile)
+	output.Println(i18n.T("sequence.report", len(result.SequenceIssues), reportFile))
+	result.SequenceReport = reportFile
+	result.Files = append(result.Files, reportFile)
+	return nil
+}
+
+// saveRouteStats calcula las estadísticas de ruta de cada pallet, las muestra
+// y las guarda en un reporte CSV.
+func saveRouteStats(coordInfos []models.CoordInfo, baseName string, result *CoordsResult) error {
+	result.RouteStats = coords.PalletStats(coordInfos)
+
+	for _, s := range result.RouteStats {
+		output.Println("\n" + i18n.T("stats.route", s.Pallet, s.Stops, s.LengthKm, s.LongestLegKm, s.AvgLegKm))
+		output.Println("  " + i18n.T("stats.area", s.Centroid.Lat, s.Centroid.Lon, s.HullAreaKm2, s.StopsPerKm2))
+		output.Println("  " + i18n.T("stats.bbox", s.BBox.MinLat, s.BBox.MinLon, s.BBox.MaxLat, s.BBox.MaxLon))
+		slog.Info("estadísticas de ruta", "pallet", s.Pallet, "stops", s.Stops, "length_km", s.LengthKm, "longest_leg_km", s.LongestLegKm, "hull_area_km2", s.HullAreaKm2)
+	}
+
+	reportFile := baseName + export.StatsReportSuffix
+	if err := export.WriteStatsReport(reportFile, result.RouteStats); err != nil {
+		slog.Error("error al escribir las estadísticas de ruta", "file", reportFile, "error", err)
+		return apperr.New(apperr.KindPartial, i18n.T("stats.write_error", err))
+	}
+
+	output.Println(i18n.T("stats.file_created", reportFile))
+	result.StatsReport = reportFile
+	result.Files = append(result.Files, reportFile)
+	return nil
+}
+
+func processAndSaveCoordinates(coordInfos []models.CoordInfo, baseName string, result *CoordsResult, opts CoordsOptions) error {
+	multiple := len(result.Pallets) > 1
+	filename, filenameClean := baseName+".txt", baseName+"_clean.txt"
+	result.Coordinates = coordInfos
+
+	// Al reintentar, el archivo principal ya se escribió
+	if opts.retry == nil {
+		err := writeCoordinatesFile(filename, coordinatesText(coordInfos, multiple))
+		if err != nil {
+			slog.Error("error al escribir el archivo de coordenadas", "file", filename, "error", err)
+			return apperr.New(apperr.KindIO, i18n.T("coords.write_error", err))
+		}
+		result.Files = append(result.Files, filename)
+
+		slog.Info("archivo de coordenadas generado", "file", filename, "coordinates", len(coordInfos))
+		output.Success(i18n.T("coords.success", len(coordInfos)))
+		output.Println(i18n.T("coords.file_created", filename))
+	}
+
+	var partialErr error
+	fail := func(step string, err error) {
+		partialErr = err
+		result.failed = append(result.failed, step)
+		output.Warning(partialErr.Error())
+	}
+
+	if opts.writes(stepClean) {
+		if err := writeCoordinatesFile(filenameClean, coordinatesCleanText(coordInfos)); err != nil {
+			slog.Error("error al escribir el archivo limpio", "file", filenameClean, "error", err)
+			fail(stepClean, apperr.New(apperr.KindPartial, i18n.T("coords.write_clean_error", err)))
+		} else {
+			result.Files = append(result.Files, filenameClean)
+			output.Println(i18n.T("coords.clean_created", filenameClean))
+		}
+	}
+
+	if opts.writes(stepStats) {
+		if err := saveRouteStats(coordInfos, baseName, result); err != nil {
+			fail(stepStats, err)
+		}
+	}
+
+	for _, format := range opts.Export.Formats {
+		if !opts.writes(stepExport + format) {
+			continue
+		}
+		exportFile, err := export.Write(format, baseName, coordInfos, opts.Export)
+		if err != nil {
+			slog.Error("error al exportar las coordenadas", "format", format, "error", err)
+			fail(stepExport+format, apperr.New(apperr.KindPartial, i18n.T("export.write_error", format, err)))
+			continue
+		}
+		slog.Info("coordenadas exportadas", "format", format, "file", exportFile)
+		output.Println(i18n.T("export.file_created", exportFile))
+		result.Files = append(result.Files, exportFile)
+	}
+
+	if multiple && opts.writes(stepPallets) {
+		if err := savePalletFiles(coordInfos, baseName, result, opts); err != nil {
+			fail(stepPallets, err)
+		}
+	}
+
+	if opts.JSON {
+		if err := output.PrintJSON(result); err != nil {
+			return apperr.Wrap(apperr.KindIO, err)
+		}
+	}
+
+	// Los errores parciales ya se avisaron: el mapa se genera igual y se
+	// devuelven al final, salvo que falle el mapa
+	if !opts.writes(stepMap) {
+		return partialErr
+	}
+	if opts.Map || output.Confirm("\n"+i18n.T("coords.ask_map")) {
+		output.ClearScreen()
+		output.Title(i18n.T("map.title"))
+		if err := generarMapa(filenameClean, strings.TrimSuffix(filenameClean, ".txt")+".html", toMapCoordinates(coordInfos), opts.MapOffline); err != nil {
+			result.failed = append(result.failed, stepMap)
+			return err
+		}
+	}
+
+	return partialErr
+}
+
+// coordinatesText arma el contenido del archivo de coordenadas, con un
+// comentario por parada. La numeración empieza de nuevo en cada pallet.
+func coordinatesText(coordInfos []models.CoordInfo, multiple bool) string {
 	var coordinates []string
+	numero := 0
 	for i, info := range coordInfos {
-		coordinates = append(coordinates, fmt.Sprintf("(%.7f, %.7f) /* Orden #%d, Vehicle Location: %d */",
-			info.Lat, info.Lon, i+1, info.VehicleLocation))
+		if i == 0 || info.PalletCode != coordInfos[i-1].PalletCode {
+			numero = 0
+		}
+		numero++
+
+		comentario := fmt.Sprintf("Orden #%d, Vehicle Location: %s", numero, vehicleLocationsText(info))
+		if multiple {
+			comentario += ", Pallet: " + info.PalletCode
+		}
+		if len(info.Orders) > 0 {
+			comentario += ", Órdenes: " + strings.Join(info.OrderIDs(), " ")
+		}
+		if len(info.Flags) > 0 {
+			comentario += ", Flags: " + strings.Join(info.Flags, " ")
+		}
+		coordinates = append(coordinates, fmt.Sprintf("(%.7f, %.7f) /* %s */", info.Lat, info.Lon, comentario))
 	}
+	return "[" + strings.Join(coordinates, ", ") + "]"
+}
 
+// vehicleLocationsText devuelve el vehicle_location de la parada o, en las
+// paradas consolidadas, el de cada orden separados por espacios, como
+// "3 7 (2 órdenes)".
+func vehicleLocationsText(info models.CoordInfo) string {
+	if len(info.Orders) == 0 {
+		return strconv.Itoa(info.VehicleLocation)
+	}
+	var valores []string
+	for _, orden := range info.Orders {
+		valores = append(valores, strconv.Itoa(orden.VehicleLocation))
+	}
+	return fmt.Sprintf("%s (%d órdenes)", strings.Join(valores, " "), len(info.Orders))
+}
+
+func coordinatesCleanText(coordInfos []models.CoordInfo) string {
 	var coordinatesClean []string
 	for _, info := range coordInfos {
 		coordinatesClean = append(coordinatesClean, fmt.Sprintf("(%.7f, %.7f)", info.Lat, info.Lon))
 	}
+	return "[" + strings.Join(coordinatesClean, ", ") + "]"
+}
 
-	coordinatesStr := "[" + strings.Join(coordinates, ", ") + "]"
-	coordinatesCleanStr := "[" + strings.Join(coordinatesClean, ", ") + "]"
+func writeCoordinatesFile(filename, contenido string) error {
+	return ioutil.WriteFile(filename, []byte(contenido), 0644)
+}
 
-	var filename string
-	if len(palletCodes) == 1 {
-		filename = fmt.Sprintf("coordenadas_%s.txt", palletCodes[0])
-	} else {
-		filename = fmt.Sprintf("coordenadas_multiple_%d_pallets.txt", len(palletCodes))
+// savePalletFiles escribe, al extraer varios pallets, los archivos de
+// coordenadas y las exportaciones de cada pallet por separado, y un índice
+// combinado con una fila por pallet.
+func savePalletFiles(coordInfos []models.CoordInfo, baseName string, result *CoordsResult, opts CoordsOptions) error {
+	paradas := map[string][]models.CoordInfo{}
+	for _, info := range coordInfos {
+		paradas[info.PalletCode] = append(paradas[info.PalletCode], info)
+	}
+	sinGeolocalizacion := map[string]int{}
+	for _, m := range result.MissingGeolocation {
+		sinGeolocalizacion[m.PalletCode]++
 	}
 
-	err := ioutil.WriteFile(filename, []byte(coordinatesStr), 0644)
-	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " Error al escribir el archivo: " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+	var partialErr error
+	output.Println("")
+	for _, code := range result.Pallets {
+		fila := models.PalletFiles{
+			Pallet:             code,
+			Stops:              len(paradas[code]),
+			MissingGeolocation: sinGeolocalizacion[code],
+			Files:              []string{},
+		}
+		for _, info := range paradas[code] {
+			fila.Orders += info.OrderCount()
+		}
+		fila.Orders += fila.MissingGeolocation
+
+		if fila.Stops > 0 {
+			files, err := writePalletFiles(code, paradas[code], opts)
+			fila.Files = files
+			if err != nil {
+				slog.Error("error al escribir los archivos del pallet", "pallet", code, "error", err)
+				partialErr = apperr.New(apperr.KindPartial, i18n.T("coords.pallet_write_error", code, err))
+				output.Warning(partialErr.Error())
+			}
+			if len(files) > 0 {
+				output.Println(i18n.T("coords.pallet_files", code, fila.Stops, strings.Join(files, ", ")))
+			}
+			result.Files = append(result.Files, files...)
+		}
+		result.PalletFiles = append(result.PalletFiles, fila)
 	}
 
-	filenameClean := strings.TrimSuffix(filename, ".txt") + "_clean.txt"
-	err = ioutil.WriteFile(filenameClean, []byte(coordinatesCleanStr), 0644)
-	if err != nil {
-		fmt.Println(verde + "\n[AVISO]" + reset + " Error al escribir el archivo limpio: " + err.Error())
+	indexFile := baseName + export.IndexSuffix
+	if err := export.WriteIndex(indexFile, result.PalletFiles); err != nil {
+		slog.Error("error al escribir el índice de pallets", "file", indexFile, "error", err)
+		return apperr.New(apperr.KindPartial, i18n.T("coords.index_error", err))
 	}
+	slog.Info("índice de pallets generado", "file", indexFile, "pallets", len(result.PalletFiles))
+	output.Println(i18n.T("coords.index_created", indexFile))
+	result.Index = indexFile
+	result.Files = append(result.Files, indexFile)
 
-	fmt.Printf("\n%s[ÉXITO]%s Se encontraron %d coordenadas ordenadas por Vehicle Location.\n", verde, reset, len(coordinates))
-	fmt.Printf("Se ha creado el archivo %s con las coordenadas en el formato solicitado.\n", filename)
-	fmt.Printf("También se creó %s con un formato compatible para otras herramientas.\n", filenameClean)
+	return partialErr
+}
 
-	fmt.Print("\n¿Desea generar un mapa HTML con estas coordenadas? (s/n): ")
-	respuesta, _ := reader.ReadString('\n')
-	respuesta = strings.TrimSpace(respuesta)
+// writePalletFiles escribe los archivos de coordenadas y las exportaciones de
+// un pallet y devuelve los que se crearon.
+func writePalletFiles(code string, paradas []models.CoordInfo, opts CoordsOptions) ([]string, error) {
+	baseName, err := opts.outputBase([]string{code})
+	if err != nil {
+		return nil, err
+	}
 
-	if strings.ToLower(respuesta) == "s" || strings.ToLower(respuesta) == "si" {
-		GenerarMapaHTML(filenameClean)
+	var files []string
+	filename, filenameClean := baseName+".txt", baseName+"_clean.txt"
+	if err := writeCoordinatesFile(filename, coordinatesText(paradas, false)); err != nil {
+		return files, err
+	}
+	files = append(files, filename)
+	if err := writeCoordinatesFile(filenameClean, coordinatesCleanText(paradas)); err != nil {
+		return files, err
 	}
+	files = append(files, filenameClean)
 
-	fmt.Println("\nPresiona Enter para volver al menú principal...")
-	fmt.Scanln()
+	for _, format := range opts.Export.Formats {
+		exportFile, err := export.Write(format, baseName, paradas, opts.Export)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, exportFile)
+	}
+	return files, nil
+}
+
+// toMapCoordinates convierte las órdenes extraídas en puntos del mapa,
+// conservando los motivos de validación. Cada pallet se numera por separado.
+func toMapCoordinates(coordInfos []models.CoordInfo) []models.Coordenada {
+	var coordenadas []models.Coordenada
+	numero := 0
+	for i, info := range coordInfos {
+		if i == 0 || info.PalletCode != coordInfos[i-1].PalletCode {
+			numero = 0
+		}
+		numero++
+
+		coordenadas = append(coordenadas, models.Coordenada{
+			Lat:             info.Lat,
+			Lon:             info.Lon,
+			Index:           numero,
+			Pallet:          info.PalletCode,
+			Flags:           info.Flags,
+			OrderID:         info.OrderID,
+			TrackingCode:    info.TrackingCode,
+			CustomerName:    info.CustomerName,
+			Address:         info.Address,
+			VehicleLocation: info.VehicleLocation,
+			Orders:          info.Orders,
+		})
+	}
+	return coordenadas
 }
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+)
+
+// DiffOptions reúne las opciones de la comparación de extracciones.
+type DiffOptions struct {
+	// MapFile es el mapa HTML de diferencias a generar; vacío para omitirlo.
+	MapFile string
+	// Offline incluye Leaflet en el mapa para abrirlo sin conexión.
+	Offline bool
+	JSON    bool
+}
+
+// DiffResult es el resultado de la comparación, que se imprime en stdout con
+// la opción --json.
+type DiffResult struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+	coords.Diff
+	Map string `json:"map,omitempty"`
+}
+
+// snapshot es una extracción leída de un archivo o de la API. complete
+// indica si incluye las órdenes sin geolocalización, que las exportaciones
+// GeoJSON no tienen.
+type snapshot struct {
+	orders   []coords.SnapshotOrder
+	pallets  []string
+	complete bool
+}
+
+// CompararCoordenadas compara dos extracciones de un mismo pallet. antes y
+// despues son archivos guardados (el resumen de coords --json o una
+// exportación GeoJSON); si despues está vacío se compara con la API.
+func CompararCoordenadas(antes, despues string, opts DiffOptions) error {
+	output.ClearScreen()
+	output.Title(i18n.T("diff.title"))
+
+	previa, err := loadSnapshot(antes)
+	if err != nil {
+		return err
+	}
+
+	var actual snapshot
+	nombreDespues := despues
+	if despues == "" {
+		if len(previa.pallets) == 0 {
+			return apperr.New(apperr.KindUsage, i18n.T("diff.no_pallets", antes))
+		}
+		nombreDespues = i18n.T("diff.live")
+		actual.orders, err = liveSnapshot(previa.pallets)
+		actual.complete = true
+	} else {
+		actual, err = loadSnapshot(despues)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Las órdenes sin geolocalización solo se comparan si ambas extracciones
+	// las incluyen o si la orden está en las dos
+	previas, actuales := previa.orders, actual.orders
+	if !actual.complete {
+		previas = coords.ExcludeUnlocated(previas, actuales)
+	}
+	if !previa.complete {
+		actuales = coords.ExcludeUnlocated(actuales, previas)
+	}
+
+	result := DiffResult{
+		Before: antes,
+		After:  nombreDespues,
+		Diff:   coords.CompareSnapshots(previas, actuales),
+	}
+	slog.Info("extracciones comparadas", "before", antes, "after", nombreDespues, "added", len(result.Added), "removed", len(result.Removed), "moved", len(result.Moved), "resequenced", len(result.Resequenced))
+
+	output.Println("\n" + i18n.T("diff.summary", antes, len(previas), nombreDespues, len(actuales)))
+	printDiff(result.Diff)
+
+	if opts.MapFile != "" {
+		if err := generateDiffMap(opts.MapFile, actuales, result.Diff, opts.Offline); err != nil {
+			return err
+		}
+		result.Map = opts.MapFile
+		output.Println("\n" + i18n.T("map.file_created", opts.MapFile))
+	}
+
+	if opts.JSON {
+		if err := output.PrintJSON(result); err != nil {
+			return apperr.Wrap(apperr.KindIO, err)
+		}
+	}
+	return nil
+}
+
+func printDiff(diff coords.Diff) {
+	if diff.Empty() {
+		output.Success(i18n.T("diff.no_changes"))
+		return
+	}
+
+	if len(diff.Added) > 0 {
+		output.Println("\n" + i18n.T("diff.added", len(diff.Added)))
+		for _, o := range diff.Added {
+			output.Println("  + " + i18n.T("diff.order", o.OrderID, o.PalletCode, o.VehicleLocation))
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		output.Println("\n" + i18n.T("diff.removed", len(diff.Removed)))
+		for _, o := range diff.Removed {
+			output.Println("  - " + i18n.T("diff.order", o.OrderID, o.PalletCode, o.VehicleLocation))
+		}
+	}
+
+	if len(diff.Moved) > 0 {
+		output.Println("\n" + i18n.T("diff.moved", len(diff.Moved)))
+		for _, m := range diff.Moved {
+			switch m.Change {
+			case "geolocated":
+				output.Println("  ~ " + i18n.T("diff.geolocated_order", m.After.OrderID, m.After.PalletCode))
+			case "lost_geolocation":
+				output.Println("  ~ " + i18n.T("diff.lost_geolocation_order", m.After.OrderID, m.After.PalletCode))
+			default:
+				output.Println("  ~ " + i18n.T("diff.moved_order", m.After.OrderID, m.After.PalletCode, m.DistanceM))
+			}
+		}
+	}
+
+	if len(diff.Resequenced) > 0 {
+		output.Println("\n" + i18n.T("diff.resequenced", len(diff.Resequenced)))
+		for _, r := range diff.Resequenced {
+			output.Println("  # " + i18n.T("diff.resequenced_order", r.OrderID, r.PalletCode, r.Before, r.After))
+		}
+	}
+}
+
+// loadSnapshot lee una extracción guardada. Acepta el resumen de
+// coords --json y las exportaciones GeoJSON; los archivos .txt no sirven
+// porque no identifican todas las órdenes.
+func loadSnapshot(path string) (snapshot, error) {
+	contenido, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return snapshot{}, apperr.New(apperr.KindNotFound, i18n.T("diff.not_found", path))
+	}
+	if err != nil {
+		return snapshot{}, apperr.WithMessage(apperr.KindIO, i18n.T("diff.err_read", path), err)
+	}
+
+	var campos map[string]json.RawMessage
+	if err := json.Unmarshal(contenido, &campos); err != nil {
+		return snapshot{}, apperr.New(apperr.KindUsage, i18n.T("diff.err_format", path, i18n.T("diff.unknown_format")))
+	}
+
+	var s snapshot
+	if string(campos["type"]) == `"FeatureCollection"` {
+		s, err = snapshotFromGeoJSON(path, contenido)
+		if err != nil {
+			return snapshot{}, err
+		}
+	} else {
+		// Un resumen sin órdenes geolocalizadas trae "coordinates" vacío o null
+		_, conCoordenadas := campos["coordinates"]
+		var result CoordsResult
+		if err := json.Unmarshal(contenido, &result); err != nil || !conCoordenadas || result.Pallets == nil {
+			return snapshot{}, apperr.New(apperr.KindUsage, i18n.T("diff.err_format", path, i18n.T("diff.unknown_format")))
+		}
+		s = snapshot{
+			orders:   coords.SnapshotFromCoordInfos(result.Coordinates, result.MissingGeolocation),
+			pallets:  result.Pallets,
+			complete: true,
+		}
+	}
+
+	// Las órdenes se identifican por su ID
+	for _, o := range s.orders {
+		if o.OrderID == "" {
+			return snapshot{}, apperr.New(apperr.KindUsage, i18n.T("diff.err_format", path, i18n.T("diff.no_order_id")))
+		}
+	}
+	return s, nil
+}
+
+func snapshotFromGeoJSON(path string, contenido []byte) (snapshot, error) {
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties struct {
+				OrderID          string   `json:"order_id"`
+				Pallet           string   `json:"pallet"`
+				VehicleLocation  int      `json:"vehicle_location"`
+				OrderIDs         []string `json:"order_ids"`
+				VehicleLocations []int    `json:"vehicle_locations"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(contenido, &collection); err != nil {
+		return snapshot{}, apperr.New(apperr.KindUsage, i18n.T("diff.err_format", path, err))
+	}
+
+	var orders []coords.SnapshotOrder
+	var pallets []string
+	vistos := map[string]bool{}
+	for _, f := range collection.Features {
+		if f.Geometry.Type != "Point" {
+			continue
+		}
+		var punto [2]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &punto); err != nil {
+			return snapshot{}, apperr.New(apperr.KindUsage, i18n.T("diff.err_format", path, err))
+		}
+
+		p := f.Properties
+		if p.Pallet != "" && !vistos[p.Pallet] {
+			vistos[p.Pallet] = true
+			pallets = append(pallets, p.Pallet)
+		}
+
+		ids, vls := p.OrderIDs, p.VehicleLocations
+		if len(ids) == 0 {
+			ids, vls = []string{p.OrderID}, []int{p.VehicleLocation}
+		}
+		for i, id := range ids {
+			o := coords.SnapshotOrder{OrderID: id, PalletCode: p.Pallet, Lat: punto[1], Lon: punto[0]}
+			if i < len(vls) {
+				o.VehicleLocation = vls[i]
+			}
+			orders = append(orders, o)
+		}
+	}
+
+	return snapshot{orders: orders, pallets: pallets}, nil
+}
+
+// liveSnapshot consulta a la API las órdenes actuales de los pallets.
+func liveSnapshot(pallets []string) ([]coords.SnapshotOrder, error) {
+	output.Println("\n" + i18n.T("coords.querying", len(pallets), strings.Join(pallets, ", ")))
+
+	// Un pallet que ya no tiene órdenes se compara como vacío.
+	responseData, err := fetchDeliveryOrders(pallets)
+	if apperr.KindOf(err) == apperr.KindNotFound {
+		return []coords.SnapshotOrder{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotFromResponse(responseData), nil
+}
+
+func snapshotFromResponse(responseData models.DeliveryOrderResponse) []coords.SnapshotOrder {
+	orders := []coords.SnapshotOrder{}
+	for _, item := range responseData.Items {
+		orders = append(orders, coords.SnapshotOrder{
+			OrderID:         item.ID.String(),
+			PalletCode:      item.PalletCode,
+			VehicleLocation: item.VehicleLocation,
+			Lat:             item.Destination.GeoLocation.Lat,
+			Lon:             item.Destination.GeoLocation.Lon,
+		})
+	}
+	return orders
+}
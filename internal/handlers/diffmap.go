@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+// diffPoint es una orden en el mapa de diferencias. Prev* guardan la
+// posición y el vehicle_location anteriores cuando cambiaron; Change indica si
+// una orden movida ganó o perdió su geolocalización.
+type diffPoint struct {
+	OrderID         string  `json:"id"`
+	PalletCode      string  `json:"pallet"`
+	Status          string  `json:"status"`
+	Lat             float64 `json:"lat"`
+	Lon             float64 `json:"lon"`
+	PrevLat         float64 `json:"prevLat,omitempty"`
+	PrevLon         float64 `json:"prevLon,omitempty"`
+	VehicleLocation int     `json:"vl"`
+	PrevVL          *int    `json:"prevVl,omitempty"`
+	DistanceM       float64 `json:"distance,omitempty"`
+	Change          string  `json:"change,omitempty"`
+}
+
+func diffPoints(after []coords.SnapshotOrder, diff coords.Diff) []diffPoint {
+	agregadas := map[string]bool{}
+	for _, o := range diff.Added {
+		agregadas[o.OrderID] = true
+	}
+	movidas := map[string]coords.MovedOrder{}
+	for _, m := range diff.Moved {
+		movidas[m.After.OrderID] = m
+	}
+	resecuenciadas := map[string]coords.ResequencedOrder{}
+	for _, r := range diff.Resequenced {
+		resecuenciadas[r.OrderID] = r
+	}
+
+	var puntos []diffPoint
+	for _, o := range after {
+		if o.Lat == 0 || o.Lon == 0 {
+			continue
+		}
+		p := diffPoint{
+			OrderID:         o.OrderID,
+			PalletCode:      o.PalletCode,
+			Status:          "unchanged",
+			Lat:             o.Lat,
+			Lon:             o.Lon,
+			VehicleLocation: o.VehicleLocation,
+		}
+		if agregadas[o.OrderID] {
+			p.Status = "added"
+		}
+		if m, ok := movidas[o.OrderID]; ok {
+			p.Status = "moved"
+			p.PrevLat, p.PrevLon, p.DistanceM = m.Before.Lat, m.Before.Lon, m.DistanceM
+			if m.Change != "moved" {
+				p.Change = m.Change
+			}
+		}
+		if r, ok := resecuenciadas[o.OrderID]; ok {
+			if p.Status == "unchanged" {
+				p.Status = "resequenced"
+			}
+			previo := r.Before
+			p.PrevVL = &previo
+		}
+		puntos = append(puntos, p)
+	}
+
+	// Las órdenes que perdieron la geolocalización se muestran donde estaban
+	for _, m := range diff.Moved {
+		if m.Change != "lost_geolocation" {
+			continue
+		}
+		p := diffPoint{
+			OrderID:         m.After.OrderID,
+			PalletCode:      m.After.PalletCode,
+			Status:          "moved",
+			Lat:             m.Before.Lat,
+			Lon:             m.Before.Lon,
+			VehicleLocation: m.After.VehicleLocation,
+			Change:          m.Change,
+		}
+		if r, ok := resecuenciadas[m.After.OrderID]; ok {
+			previo := r.Before
+			p.PrevVL = &previo
+		}
+		puntos = append(puntos, p)
+	}
+
+	for _, o := range diff.Removed {
+		if o.Lat == 0 || o.Lon == 0 {
+			continue
+		}
+		puntos = append(puntos, diffPoint{
+			OrderID:         o.OrderID,
+			PalletCode:      o.PalletCode,
+			Status:          "removed",
+			Lat:             o.Lat,
+			Lon:             o.Lon,
+			VehicleLocation: o.VehicleLocation,
+		})
+	}
+
+	return puntos
+}
+
+func generateDiffMap(fileName string, after []coords.SnapshotOrder, diff coords.Diff, offline bool) error {
+	leaflet, err := loadLeaflet(offline)
+	if err != nil {
+		return err
+	}
+
+	htmlTemplate := `<!DOCTYPE html>
+<html lang="{{lang}}">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{t "diff.html.title"}}</title>
+    {{template "leaflet-css" .}}
+    <style>
+        body {
+            margin: 0;
+            padding: 0;
+            font-family: Arial, sans-serif;
+        }
+        #map {
+            height: 600px;
+            width: 100%;
+        }
+        .info-panel {
+            padding: 10px;
+            background: white;
+            border-radius: 5px;
+            box-shadow: 0 0 15px rgba(0,0,0,0.2);
+            margin-bottom: 10px;
+        }
+        .legend span {
+            display: inline-block;
+            width: 12px;
+            height: 12px;
+            border-radius: 50%;
+            margin: 0 4px 0 12px;
+            vertical-align: middle;
+        }
+    </style>
+</head>
+<body>
+    <div class="info-panel">
+        <h1>{{t "diff.html.title"}}</h1>
+        <div class="legend" id="legend"></div>
+    </div>
+
+    {{template "leaflet-fallback" .}}
+    <div id="map"></div>
+
+    {{template "leaflet-js" .}}
+
+    <script>
+        const map = L.map('map');
+
+        watchTiles(L.tileLayer('https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png', {
+            attribution: '&copy; <a href="https://www.openstreetmap.org/copyright">OpenStreetMap</a> contributors'
+        })).addTo(map);
+
+        const points = {{.Points}};
+        const labels = {{.Labels}};
+        const colors = {
+            unchanged: '#95a5a6',
+            added: '#27ae60',
+            removed: '#c0392b',
+            moved: '#e67e22',
+            resequenced: '#2980b9'
+        };
+
+        function escapeHTML(value) {
+            return String(value).replace(/[&<>"']/g, c => ({'&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;'})[c]);
+        }
+
+        const group = L.featureGroup().addTo(map);
+        const counts = {};
+
+        points.forEach(p => {
+            counts[p.status] = (counts[p.status] || 0) + 1;
+
+            // Número actual, con el anterior si el vehicle_location cambió
+            let numero = '' + p.vl;
+            if (p.prevVl !== undefined) {
+                numero = p.prevVl + '&rarr;' + p.vl;
+            }
+
+            const icon = L.divIcon({
+                html: '<div style="background-color: ' + colors[p.status] + '; color: white; border-radius: 12px; min-width: 24px; height: 24px; padding: 0 4px; display: flex; align-items: center; justify-content: center; font-weight: bold; font-size: 11px; box-shadow: 0 0 3px rgba(0,0,0,0.5);">' + numero + '</div>',
+                className: '',
+                iconSize: null,
+                iconAnchor: [12, 12]
+            });
+
+            let popup = '<b>' + {{t "diff.html.order"}} + ' ' + escapeHTML(p.id) + '</b><br>Pallet: ' + escapeHTML(p.pallet) + '<br>' + labels[p.change || p.status] + '<br>Vehicle Location: ' + numero;
+            if (p.distance) {
+                popup += '<br>' + {{t "diff.html.distance"}} + ': ' + p.distance.toFixed(0) + ' m';
+            }
+
+            L.marker([p.lat, p.lon], {icon: icon}).bindPopup(popup).addTo(group);
+
+            // Línea desde la posición anterior de las órdenes movidas
+            if (p.status === 'moved' && !p.change) {
+                L.circleMarker([p.prevLat, p.prevLon], {radius: 5, color: colors.moved, fillOpacity: 0.3}).addTo(group);
+                L.polyline([[p.prevLat, p.prevLon], [p.lat, p.lon]], {color: colors.moved, weight: 2, dashArray: '6 4'}).addTo(group);
+            }
+        });
+
+        const legend = document.getElementById('legend');
+        Object.keys(colors).forEach(status => {
+            legend.innerHTML += '<span style="background-color: ' + colors[status] + ';"></span>' + labels[status] + ' (' + (counts[status] || 0) + ')';
+        });
+
+        if (points.length > 0) {
+            map.fitBounds(group.getBounds());
+        } else {
+            map.setView([-33.45, -70.65], 11);
+        }
+    </script>
+</body>
+</html>`
+
+	tmpl, err := parseMapTemplate("diff", htmlTemplate, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("map.err_template"), err)
+	}
+
+	labels := map[string]string{}
+	for _, status := range []string{"unchanged", "added", "removed", "moved", "resequenced", "geolocated", "lost_geolocation"} {
+		labels[status] = i18n.T("diff.html." + status)
+	}
+
+	archivoHTML, err := os.Create(fileName)
+	if err != nil {
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_create"), err)
+	}
+	defer archivoHTML.Close()
+
+	datos := struct {
+		Points []diffPoint
+		Labels map[string]string
+		leafletPage
+	}{
+		Points:      diffPoints(after, diff),
+		Labels:      labels,
+		leafletPage: leaflet,
+	}
+	if datos.Points == nil {
+		datos.Points = []diffPoint{}
+	}
+
+	if err := tmpl.Execute(archivoHTML, datos); err != nil {
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_render"), err)
+	}
+
+	slog.Info("mapa de diferencias generado", "file", fileName, "points", len(datos.Points))
+	return nil
+}
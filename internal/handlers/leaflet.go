@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/mapassets"
+)
+
+// leafletPage reúne lo que necesitan las plantillas de los mapas para cargar
+// Leaflet, desde el CDN o incluido en el HTML.
+type leafletPage struct {
+	Offline    bool
+	LeafletJS  template.JS
+	LeafletCSS template.CSS
+	// Icons son las imágenes del marcador por defecto como data URI.
+	Icons map[string]string
+}
+
+// loadLeaflet prepara Leaflet para un mapa. Sin conexión incluye los archivos
+// del binario, que deben haberse descargado al compilar.
+func loadLeaflet(offline bool) (leafletPage, error) {
+	if !offline {
+		return leafletPage{}, nil
+	}
+
+	l, err := mapassets.Load()
+	if errors.Is(err, mapassets.ErrMissing) {
+		return leafletPage{}, apperr.New(apperr.KindUsage, i18n.T("map.offline_missing"))
+	}
+	if err != nil {
+		return leafletPage{}, apperr.WithMessage(apperr.KindIO, i18n.T("map.offline_missing"), err)
+	}
+
+	return leafletPage{
+		Offline: true,
+		// Los archivos de Leaflet son de confianza: vienen incluidos en el binario
+		LeafletJS:  template.JS(l.JS),
+		LeafletCSS: template.CSS(l.CSS),
+		Icons:      l.Icons,
+	}, nil
+}
+
+// leafletTemplates se agregan a las plantillas de los mapas. "leaflet-css" y
+// "leaflet-js" cargan Leaflet; "leaflet-fallback" muestra un aviso si Leaflet
+// no se pudo cargar o si fallan todas las imágenes del mapa de fondo, y
+// "watchTiles" debe llamarse con cada capa de teselas.
+const leafletTemplates = `
+{{define "leaflet-css"}}
+    {{- if .Offline}}
+    <style>{{.LeafletCSS}}</style>
+    {{- else}}
+    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/leaflet/{{leafletVersion}}/leaflet.min.css" />
+    {{- end}}
+    <style>
+        .map-warning {
+            display: none;
+            padding: 8px 10px;
+            background: #fff3cd;
+            color: #856404;
+            border: 1px solid #ffeeba;
+            border-radius: 4px;
+            margin-bottom: 10px;
+        }
+    </style>
+{{- end}}
+
+{{define "leaflet-js"}}
+    {{- if .Offline}}
+    <script>{{.LeafletJS}}</script>
+    {{- if .Icons}}
+    <script>
+        L.Icon.Default.mergeOptions({
+            iconUrl: {{index .Icons "marker-icon.png"}},
+            iconRetinaUrl: {{index .Icons "marker-icon-2x.png"}},
+            shadowUrl: {{index .Icons "marker-shadow.png"}}
+        });
+    </script>
+    {{- end}}
+    {{- else}}
+    <script src="https://cdnjs.cloudflare.com/ajax/libs/leaflet/{{leafletVersion}}/leaflet.min.js"></script>
+    {{- end}}
+    <script>
+        // Sin Leaflet no se puede dibujar el mapa: se avisa en lugar de dejarlo en blanco
+        if (typeof L === 'undefined') {
+            const aviso = document.getElementById('map-warning');
+            aviso.textContent = {{t "map.html.leaflet_failed"}};
+            aviso.style.display = 'block';
+        }
+
+        // Aviso cuando no se puede cargar ninguna imagen del mapa de fondo
+        let tilesLoaded = 0;
+        function watchTiles(layer) {
+            layer.on('tileload', () => {
+                tilesLoaded++;
+                document.getElementById('map-warning').style.display = 'none';
+            });
+            layer.on('tileerror', () => {
+                if (tilesLoaded === 0) {
+                    const aviso = document.getElementById('map-warning');
+                    aviso.textContent = {{t "map.html.tiles_failed"}};
+                    aviso.style.display = 'block';
+                }
+            });
+            return layer;
+        }
+    </script>
+{{- end}}
+
+{{define "leaflet-fallback"}}
+    <div id="map-warning" class="map-warning"></div>
+{{- end}}
+`
+
+// parseMapTemplate interpreta la plantilla de un mapa junto con las de
+// Leaflet.
+func parseMapTemplate(nombre, htmlTemplate string, funcs template.FuncMap) (*template.Template, error) {
+	base := template.FuncMap{
+		"t":              i18n.T,
+		"lang":           i18n.Lang,
+		"leafletVersion": func() string { return mapassets.LeafletVersion },
+	}
+	for k, v := range funcs {
+		base[k] = v
+	}
+
+	tmpl, err := template.New(nombre).Funcs(base).Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Parse(leafletTemplates)
+}
@@ -1,60 +1,83 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"html/template"
-	"io/ioutil"
+	"log/slog"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/export"
+	"github.com/Cait-dev/alas-tools-cli/internal/geo"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/importer"
 	"github.com/Cait-dev/alas-tools-cli/internal/models"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
 )
 
-func GenerarMapaHTML(coordenadasTXT string) {
-	fmt.Print("\033[H\033[2J")
+// MapOptions reúne las opciones del mapa generado desde un archivo.
+type MapOptions struct {
+	Input importer.Options
+	// Offline incluye Leaflet en el HTML para abrirlo sin conexión.
+	Offline bool
+}
 
-	verde := "\033[32m"
-	reset := "\033[0m"
-	titulo := verde + "[Generar Mapa HTML]" + reset
+// GenerarMapaHTML genera el mapa de un archivo de coordenadas, CSV, GeoJSON,
+// KML o GPX.
+func GenerarMapaHTML(coordenadasTXT string, opts MapOptions) error {
+	output.ClearScreen()
 
-	fmt.Println("\n" + titulo)
-	fmt.Println("\nEsta herramienta genera un mapa HTML interactivo a partir de un archivo de coordenadas.")
+	output.Title(i18n.T("map.title"))
+	output.Println("\n" + i18n.T("map.intro"))
 
-	if coordenadasTXT == "" {
-		fmt.Print("\nIngrese la ruta del archivo de coordenadas (ej. coordenadas_pl202505danl001.txt): ")
+	if coordenadasTXT == "" && output.IsInteractive() {
+		output.Print("\n" + i18n.T("map.prompt"))
 		fmt.Scanln(&coordenadasTXT)
 	}
 
 	if coordenadasTXT == "" {
-		fmt.Println(verde + "\n[ERROR]" + reset + " Debe proporcionar un archivo de coordenadas.")
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		return apperr.New(apperr.KindUsage, i18n.T("map.no_file"))
+	}
+
+	coordenadas, err := readStops(coordenadasTXT, opts.Input)
+	if err != nil {
+		return err
 	}
 
-	if _, err := os.Stat(coordenadasTXT); os.IsNotExist(err) {
-		fmt.Println(verde + "\n[ERROR]" + reset + " El archivo especificado no existe: " + coordenadasTXT)
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+	nombreHTML := strings.TrimSuffix(coordenadasTXT, filepath.Ext(coordenadasTXT)) + ".html"
+	return generarMapa(coordenadasTXT, nombreHTML, coordenadas, opts.Offline)
+}
+
+// readStops lee las paradas de un archivo para un mapa.
+func readStops(path string, opts importer.Options) ([]models.Coordenada, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, apperr.New(apperr.KindNotFound, i18n.T("map.not_found", path))
 	}
 
-	coordenadas, err := parseCoordinatesFile(coordenadasTXT)
+	coordenadas, format, err := importer.Read(path, opts)
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return nil, apperr.WithMessage(apperr.KindIO, i18n.T("map.err_read"), err)
+	}
 	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		slog.Warn("archivo de coordenadas mal formado", "file", path, "format", format, "error", err)
+		return nil, apperr.New(apperr.KindUsage, i18n.T("map.err_parse", path, err))
 	}
+	slog.Debug("archivo de coordenadas leído", "file", path, "format", format, "points", len(coordenadas))
 
 	if len(coordenadas) == 0 {
-		fmt.Println(verde + "\n[ERROR]" + reset + " No se pudieron extraer coordenadas válidas del archivo.")
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		return nil, apperr.New(apperr.KindNotFound, i18n.T("map.no_coords"))
 	}
+	return coordenadas, nil
+}
 
+// generarMapa escribe el mapa HTML de las coordenadas e informa el resultado.
+// origen solo se usa para el log.
+func generarMapa(origen, nombreHTML string, coordenadas []models.Coordenada, offline bool) error {
 	// Calcular el centro del mapa
 	var sumLat, sumLon float64
 	for _, coord := range coordenadas {
@@ -68,79 +91,68 @@ func GenerarMapaHTML(coordenadasTXT string) {
 		Coordenadas: coordenadas,
 		CentroLat:   centroLat,
 		CentroLon:   centroLon,
+		Pallets:     mapPallets(coordenadas),
 	}
 
 	// Generar HTML
-	nombreBase := strings.TrimSuffix(coordenadasTXT, ".txt")
-	nombreHTML := nombreBase + ".html"
-
-	err = generateHTMLMap(nombreHTML, datos)
+	err := generateHTMLMap(nombreHTML, datos, offline)
 	if err != nil {
-		fmt.Println(verde + "\n[ERROR]" + reset + " " + err.Error())
-		fmt.Println("\nPresiona Enter para volver al menú principal...")
-		fmt.Scanln()
-		return
+		slog.Error("error al generar el mapa", "file", nombreHTML, "error", err)
+		return err
 	}
 
-	fmt.Printf("\n%s[ÉXITO]%s Se ha generado el mapa con %d puntos.\n", verde, reset, len(coordenadas))
-	fmt.Printf("Archivo HTML creado: %s\n", nombreHTML)
-	fmt.Println("\nPuedes abrir este archivo en cualquier navegador para ver el mapa interactivo.")
+	slog.Info("mapa generado", "source", origen, "file", nombreHTML, "points", len(coordenadas), "offline", offline)
 
-	fmt.Println("\nPresiona Enter para volver al menú principal...")
-	fmt.Scanln()
-}
-
-func parseCoordinatesFile(filePath string) ([]models.Coordenada, error) {
-	contenido, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("error al leer el archivo: %w", err)
-	}
+	output.Success(i18n.T("map.success", len(coordenadas)))
+	output.Println(i18n.T("map.file_created", nombreHTML))
+	output.Println("\n" + i18n.T("map.open_hint"))
 
-	coordStr := string(contenido)
-	coordStr = strings.TrimSpace(coordStr)
-
-	coordStr = strings.TrimPrefix(coordStr, "[")
-	coordStr = strings.TrimSuffix(coordStr, "]")
-
-	paresCoordenadas := strings.Split(coordStr, "), (")
-
-	for i := range paresCoordenadas {
-		paresCoordenadas[i] = strings.Trim(paresCoordenadas[i], "()")
-	}
-
-	var coordenadas []models.Coordenada
-
-	for i, par := range paresCoordenadas {
-		partes := strings.Split(par, ", ")
-		if len(partes) != 2 {
-			continue
-		}
-
-		lat, err1 := strconv.ParseFloat(partes[0], 64)
-		lon, err2 := strconv.ParseFloat(partes[1], 64)
+	return nil
+}
 
-		if err1 != nil || err2 != nil {
-			continue
+// mapPallets resume los pallets del mapa en el orden en que aparecen. Cada
+// uno recibe un color de la paleta de exportación cuando hay más de uno, y el
+// largo de su ruta se mide uniendo sus paradas en orden.
+func mapPallets(coordenadas []models.Coordenada) []models.MapPallet {
+	var pallets []models.MapPallet
+	indices := map[string]int{}
+	ultimo := map[string]geo.Point{}
+	for _, coord := range coordenadas {
+		punto := geo.Point{Lat: coord.Lat, Lon: coord.Lon}
+		i, ok := indices[coord.Pallet]
+		if !ok {
+			i = len(pallets)
+			indices[coord.Pallet] = i
+			pallets = append(pallets, models.MapPallet{Code: coord.Pallet, Color: export.PalletColor(i)})
+		} else {
+			pallets[i].LengthKm += geo.HaversineKm(ultimo[coord.Pallet], punto)
 		}
+		ultimo[coord.Pallet] = punto
 
-		coordenadas = append(coordenadas, models.Coordenada{
-			Lat:   lat,
-			Lon:   lon,
-			Index: i + 1,
-		})
+		pallets[i].Stops++
+		pallets[i].Orders += max(1, len(coord.Orders))
 	}
 
-	return coordenadas, nil
+	// Con un solo pallet se conservan los colores de siempre
+	if len(pallets) == 1 {
+		pallets[0].Color = ""
+	}
+	return pallets
 }
 
-func generateHTMLMap(fileName string, datos models.MapData) error {
+func generateHTMLMap(fileName string, datos models.MapData, offline bool) error {
+	leaflet, err := loadLeaflet(offline)
+	if err != nil {
+		return err
+	}
+
 	htmlTemplate := `<!DOCTYPE html>
-<html lang="es">
+<html lang="{{lang}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Mapa de Coordenadas</title>
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/leaflet/1.9.4/leaflet.min.css" />
+    <title>{{t "map.html.title"}}</title>
+    {{template "leaflet-css" .}}
     <style>
         body {
             margin: 0;
@@ -175,21 +187,117 @@ func generateHTMLMap(fileName string, datos models.MapData) error {
         button:hover {
             background-color: #45a049;
         }
+        .layout {
+            display: flex;
+            gap: 10px;
+        }
+        .map-container {
+            flex: 1;
+            min-width: 0;
+        }
+        .sidebar {
+            width: 300px;
+            height: 600px;
+            display: flex;
+            flex-direction: column;
+            border: 1px solid #ddd;
+            border-radius: 4px;
+        }
+        .sidebar input {
+            margin: 8px;
+            padding: 6px 8px;
+            font-size: 14px;
+        }
+        .search-count {
+            margin: 0 8px 6px;
+            font-size: 12px;
+            color: #666;
+        }
+        .stop-list {
+            list-style: none;
+            margin: 0;
+            padding: 0;
+            overflow-y: auto;
+            flex: 1;
+        }
+        .stop-list li {
+            padding: 6px 8px;
+            border-top: 1px solid #eee;
+            font-size: 13px;
+            cursor: pointer;
+        }
+        .stop-list li:hover {
+            background-color: #f0f7ff;
+        }
+        .stop-list small {
+            color: #666;
+        }
+        .stop-flagged {
+            color: #e67e22;
+        }
+        .legend {
+            padding: 8px;
+            border-bottom: 1px solid #ddd;
+            max-height: 200px;
+            overflow-y: auto;
+        }
+        .legend-header, .legend-row {
+            display: flex;
+            align-items: center;
+            justify-content: space-between;
+            gap: 6px;
+        }
+        .legend-row {
+            margin-top: 6px;
+            font-size: 13px;
+        }
+        .legend-row small {
+            color: #666;
+        }
+        .legend-swatch {
+            display: inline-block;
+            width: 12px;
+            height: 12px;
+            border-radius: 2px;
+            margin: 0 4px;
+        }
+        .legend-button {
+            padding: 2px 8px;
+            font-size: 12px;
+            margin: 0;
+        }
     </style>
 </head>
 <body>
     <div class="info-panel">
-        <h1>Mapa de Coordenadas</h1>
+        <h1>{{t "map.html.title"}}</h1>
     </div>
     
     <div class="button-container">
-        <button id="toggle-line">Mostrar/Ocultar Línea</button>
-        <button id="fit-bounds">Ajustar Vista</button>
+        <button id="toggle-line">{{t "map.html.toggle"}}</button>
+        <button id="fit-bounds">{{t "map.html.fit"}}</button>
     </div>
     
-    <div id="map"></div>
+    <div class="layout">
+        <div class="sidebar">
+            <div class="legend">
+                <div class="legend-header">
+                    <b>{{t "map.html.legend"}}</b>
+                    <button id="show-all" class="legend-button">{{t "map.html.show_all"}}</button>
+                </div>
+                <div id="legend"></div>
+            </div>
+            <input id="search" type="search" placeholder="{{t "map.html.search"}}">
+            <div id="search-count" class="search-count"></div>
+            <ul id="stop-list" class="stop-list"></ul>
+        </div>
+        <div class="map-container">
+            {{template "leaflet-fallback" .}}
+            <div id="map"></div>
+        </div>
+    </div>
     
-    <script src="https://cdnjs.cloudflare.com/ajax/libs/leaflet/1.9.4/leaflet.min.js"></script>
+    {{template "leaflet-js" .}}
     
     <script>
         // Inicializar el mapa
@@ -199,89 +307,304 @@ func generateHTMLMap(fileName string, datos models.MapData) error {
         const baseMap = L.tileLayer('https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png', {
             attribution: '&copy; <a href="https://www.openstreetmap.org/copyright">OpenStreetMap</a> contributors',
             opacity: 1
-        }).addTo(map);
+        });
+        watchTiles(baseMap).addTo(map);
         
         // Añadir capa humanitaria (con más detalles urbanos)
         const hotMap = L.tileLayer('https://{s}.tile.openstreetmap.fr/hot/{z}/{x}/{y}.png', {
             attribution: '&copy; <a href="https://www.openstreetmap.org/copyright">OpenStreetMap</a> contributors, Tiles style by <a href="https://www.hotosm.org/" target="_blank">Humanitarian OpenStreetMap Team</a>',
             opacity: 0.7
-        }).addTo(map);
-        
-        // Crear un grupo para todos los marcadores
-        const markersGroup = L.layerGroup().addTo(map);
+        });
+        watchTiles(hotMap).addTo(map);
         
-        // Coordenadas
+        // Coordenadas con los datos de sus órdenes
         const coordinates = [
             {{range .Coordenadas}}
-            [{{.Lat}}, {{.Lon}}],
+            {lat: {{.Lat}}, lon: {{.Lon}}, index: {{.Index}}, pallet: {{.Pallet}}, flags: {{.Flags}}, orderId: {{.OrderID}}, tracking: {{.TrackingCode}}, customer: {{.CustomerName}}, address: {{.Address}}, vl: {{.VehicleLocation}}, orders: {{.Orders}}},
             {{end}}
         ];
         
+        // Descripción de los motivos de validación
+        const flagLabels = {{flagLabels}};
+        
+        // Cada pallet es una capa con sus marcadores y su línea
+        const pallets = {{.Pallets}};
+        const layers = {};
+        pallets.forEach(p => {
+            layers[p.code] = {
+                color: p.color,
+                markers: L.featureGroup().addTo(map),
+                line: L.layerGroup().addTo(map),
+                visible: true
+            };
+        });
+        
+        // Los datos vienen de la API y pueden contener HTML
+        function escapeHTML(value) {
+            return String(value).replace(/[&<>"']/g, c => ({'&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;'})[c]);
+        }
+        
+        // Línea del popup con una etiqueta, omitida si no hay valor
+        function field(label, value) {
+            return value ? '<br>' + label + ': ' + escapeHTML(value) : '';
+        }
+        
+        // Detalle de una orden de una parada consolidada
+        function orderLine(order) {
+            let line = '<li><b>' + escapeHTML(order.order_id) + '</b>';
+            if (order.vehicle_location) {
+                line += ' (VL ' + order.vehicle_location + ')';
+            }
+            [order.tracking_code, order.customer_name, order.address].forEach(value => {
+                if (value) {
+                    line += ' &middot; ' + escapeHTML(value);
+                }
+            });
+            return line + '</li>';
+        }
+        
+        function popupHTML(coord, orders, flags) {
+            let popup = '<b>' + {{t "map.html.point"}} + ' ' + coord.index + '</b>';
+            popup += field('Pallet', coord.pallet);
+            if (orders.length > 1) {
+                popup += '<br>' + {{t "map.html.orders"}} + ' (' + orders.length + '):<ul style="margin: 4px 0; padding-left: 18px;">' + orders.map(orderLine).join('') + '</ul>';
+                popup += field({{t "map.html.address"}}, coord.address);
+            } else {
+                popup += field({{t "map.html.order"}}, coord.orderId);
+                popup += field({{t "map.html.tracking"}}, coord.tracking);
+                popup += field({{t "map.html.customer"}}, coord.customer);
+                popup += field({{t "map.html.address"}}, coord.address);
+                popup += field('Vehicle Location', coord.vl);
+            }
+            popup += '<br>Lat: ' + coord.lat + '<br>Lon: ' + coord.lon;
+            flags.forEach(flag => {
+                popup += '<br><span style="color: #e67e22;">&#9888; ' + escapeHTML(flagLabels[flag] || flag) + '</span>';
+            });
+            return popup;
+        }
+        
         // Añadir marcadores con números
-        coordinates.forEach((coord, index) => {
-            // Crear un div personalizado con un círculo de fondo azul claro y número
+        const markers = [];
+        coordinates.forEach(coord => {
+            const flags = coord.flags || [];
+            const orders = coord.orders || [];
+            
+            // Las paradas consolidadas muestran la cantidad de órdenes junto al número
+            const badge = orders.length > 1 ? '<span style="position: absolute; top: -8px; right: -10px; background-color: #2c3e50; color: white; border-radius: 8px; padding: 0 4px; font-size: 10px;">&times;' + orders.length + '</span>' : '';
+            
+            // Crear un div personalizado con un círculo numerado: azul claro, o naranja si la coordenada fue marcada.
+            // Con varios pallets el círculo toma el color del pallet y las marcadas llevan un borde naranja
+            const layer = layers[coord.pallet];
+            let color = flags.length > 0 ? '#e67e22' : '#3399ff';
+            let border = '';
+            if (layer.color) {
+                color = layer.color;
+                border = flags.length > 0 ? 'border: 3px solid #e67e22; ' : '';
+            }
             const numberIcon = L.divIcon({
-                html: '<div style="background-color: #3399ff; color: white; border-radius: 50%; width: 24px; height: 24px; display: flex; align-items: center; justify-content: center; font-weight: bold; box-shadow: 0 0 3px rgba(0,0,0,0.5);">' + (index + 1) + '</div>',
+                html: '<div style="background-color: ' + color + '; ' + border + 'box-sizing: border-box; color: white; border-radius: 50%; width: 24px; height: 24px; display: flex; align-items: center; justify-content: center; font-weight: bold; box-shadow: 0 0 3px rgba(0,0,0,0.5); position: relative;">' + coord.index + badge + '</div>',
                 className: '',
                 iconSize: [24, 24],
                 iconAnchor: [12, 12]
             });
             
             // Crear el marcador y asignar el icono personalizado
-            const marker = L.marker(coord, {
+            const marker = L.marker([coord.lat, coord.lon], {
                 icon: numberIcon
             });
+            marker.bindPopup(popupHTML(coord, orders, flags));
+            
+            // Añadir el marcador a la capa de su pallet
+            layer.markers.addLayer(marker);
+            markers.push(marker);
+        });
+        
+        // Crear una línea por pallet que conecta sus puntos en orden
+        const routes = {};
+        coordinates.forEach(coord => {
+            (routes[coord.pallet] = routes[coord.pallet] || []).push([coord.lat, coord.lon]);
+        });
+        Object.keys(routes).forEach(pallet => {
+            L.polyline(routes[pallet], {
+                color: layers[pallet].color || 'red',
+                weight: 2,
+                opacity: 0.9
+            }).addTo(layers[pallet].line);
+        });
+        
+        // Lista de paradas: al elegir una se centra el mapa y se abre su popup
+        const stopList = document.getElementById('stop-list');
+        const items = coordinates.map((coord, i) => {
+            const orders = coord.orders || [];
+            const ids = orders.length > 1 ? orders.map(o => o.order_id) : [coord.orderId];
+            const customers = orders.length > 1 ? orders.map(o => o.customer_name) : [coord.customer];
             
-            // Añadir popup con información
-            marker.bindPopup('<b>Punto ' + (index + 1) + '</b><br>Lat: ' + coord[0] + '<br>Lon: ' + coord[1]);
+            const item = document.createElement('li');
+            let title = '<b>' + coord.index + '.</b> ' + escapeHTML(ids.filter(Boolean).join(', ') || coord.lat + ', ' + coord.lon);
+            if (coord.pallet && pallets.length > 1) {
+                title += ' <small>(' + escapeHTML(coord.pallet) + ')</small>';
+            }
+            if ((coord.flags || []).length > 0) {
+                title = '<span class="stop-flagged">&#9888;</span> ' + title;
+            }
+            const detail = [customers.filter(Boolean).join(', '), coord.address].filter(Boolean).map(escapeHTML).join('<br>');
+            item.innerHTML = title + (detail ? '<br><small>' + detail + '</small>' : '');
+            item.addEventListener('click', () => {
+                map.setView(markers[i].getLatLng(), Math.max(map.getZoom(), 16));
+                markers[i].openPopup();
+            });
+            stopList.appendChild(item);
             
-            // Añadir el marcador al grupo
-            markersGroup.addLayer(marker);
+            // Texto en el que se busca
+            const tracking = orders.length > 1 ? orders.map(o => o.tracking_code) : [coord.tracking];
+            const text = [coord.pallet, coord.address].concat(ids, tracking, customers).filter(Boolean).join(' ').toLowerCase();
+            return {item: item, text: text, pallet: coord.pallet};
         });
         
-        // Crear una línea que conecta todos los puntos
-        const polyline = L.polyline(coordinates, {
-            color: 'red',
-            weight: 2,
-            opacity: 0.9
-        }).addTo(map);
+        // La búsqueda solo muestra las paradas de los pallets visibles
+        const searchCount = document.getElementById('search-count');
+        function filterStops() {
+            const terms = document.getElementById('search').value.toLowerCase().split(/\s+/).filter(Boolean);
+            let visible = 0;
+            items.forEach(entry => {
+                const match = layers[entry.pallet].visible && terms.every(term => entry.text.includes(term));
+                entry.item.style.display = match ? '' : 'none';
+                if (match) {
+                    visible++;
+                }
+            });
+            searchCount.textContent = visible + ' / ' + items.length + ' ' + {{t "map.html.stops"}};
+        }
+        document.getElementById('search').addEventListener('input', filterStops);
+        
+        // Mostrar u ocultar las capas según los pallets visibles y el botón de la línea
+        let lineVisible = true;
+        function showLayer(layer, visible) {
+            if (visible) {
+                map.addLayer(layer);
+            } else {
+                map.removeLayer(layer);
+            }
+        }
+        function updateLayers() {
+            pallets.forEach(p => {
+                const layer = layers[p.code];
+                showLayer(layer.markers, layer.visible);
+                showLayer(layer.line, layer.visible && lineVisible);
+                layer.checkbox.checked = layer.visible;
+            });
+            filterStops();
+        }
+        
+        // Ajustar la vista a los marcadores de los pallets visibles
+        function fitVisible() {
+            const bounds = L.latLngBounds([]);
+            pallets.forEach(p => {
+                if (layers[p.code].visible) {
+                    bounds.extend(layers[p.code].markers.getBounds());
+                }
+            });
+            if (bounds.isValid()) {
+                map.fitBounds(bounds);
+            }
+        }
+        
+        // Leyenda con las paradas y el largo de la ruta de cada pallet
+        const legend = document.getElementById('legend');
+        pallets.forEach(p => {
+            const layer = layers[p.code];
+            const row = document.createElement('div');
+            row.className = 'legend-row';
+            
+            layer.checkbox = document.createElement('input');
+            layer.checkbox.type = 'checkbox';
+            layer.checkbox.addEventListener('change', () => {
+                layer.visible = layer.checkbox.checked;
+                updateLayers();
+            });
+            
+            let stats = p.stops + ' ' + {{t "map.html.stops"}};
+            if (p.orders !== p.stops) {
+                stats += ' (' + p.orders + ' ' + {{t "map.html.order_count"}} + ')';
+            }
+            stats += ' &middot; ' + p.length_km.toFixed(1) + ' km';
+            const label = document.createElement('label');
+            label.innerHTML = '<span class="legend-swatch" style="background-color: ' + (p.color || 'red') + ';"></span><b>' + escapeHTML(p.code || {{t "map.html.route"}}) + '</b><br><small>' + stats + '</small>';
+            label.prepend(layer.checkbox);
+            row.appendChild(label);
+            
+            // Mostrar solo este pallet
+            if (pallets.length > 1) {
+                const solo = document.createElement('button');
+                solo.className = 'legend-button';
+                solo.textContent = {{t "map.html.solo"}};
+                solo.addEventListener('click', () => {
+                    pallets.forEach(o => {
+                        layers[o.code].visible = o.code === p.code;
+                    });
+                    updateLayers();
+                    fitVisible();
+                });
+                row.appendChild(solo);
+            }
+            legend.appendChild(row);
+        });
+        
+        const showAll = document.getElementById('show-all');
+        if (pallets.length > 1) {
+            showAll.addEventListener('click', () => {
+                pallets.forEach(p => {
+                    layers[p.code].visible = true;
+                });
+                updateLayers();
+                fitVisible();
+            });
+        } else {
+            showAll.style.display = 'none';
+        }
+        
+        updateLayers();
         
         // Ajustar el mapa para mostrar todos los marcadores
-        map.fitBounds(markersGroup.getBounds());
+        fitVisible();
         
         // Función para mostrar/ocultar la línea
-        let lineVisible = true;
         document.getElementById('toggle-line').addEventListener('click', function() {
-            if (lineVisible) {
-                map.removeLayer(polyline);
-            } else {
-                map.addLayer(polyline);
-            }
             lineVisible = !lineVisible;
+            updateLayers();
         });
         
         // Función para ajustar la vista
-        document.getElementById('fit-bounds').addEventListener('click', function() {
-            map.fitBounds(markersGroup.getBounds());
-        });
+        document.getElementById('fit-bounds').addEventListener('click', fitVisible);
     </script>
 </body>
 </html>`
 
-	tmpl, err := template.New("mapa").Parse(htmlTemplate)
+	tmpl, err := parseMapTemplate("mapa", htmlTemplate, template.FuncMap{
+		"flagLabels": func() map[string]string {
+			labels := map[string]string{}
+			for _, flag := range coords.FlagCodes {
+				labels[flag] = i18n.T("flag." + flag)
+			}
+			return labels
+		},
+	})
 	if err != nil {
-		return fmt.Errorf("error al procesar la plantilla: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("map.err_template"), err)
 	}
 
 	archivoHTML, err := os.Create(fileName)
 	if err != nil {
-		return fmt.Errorf("error al crear el archivo HTML: %w", err)
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_create"), err)
 	}
 	defer archivoHTML.Close()
 
-	err = tmpl.Execute(archivoHTML, datos)
+	err = tmpl.Execute(archivoHTML, struct {
+		models.MapData
+		leafletPage
+	}{datos, leaflet})
 	if err != nil {
-		return fmt.Errorf("error al generar el HTML: %w", err)
+		return apperr.WithMessage(apperr.KindIO, i18n.T("map.err_render"), err)
 	}
 
 	return nil
@@ -1,71 +1,58 @@
 package handlers
 
 import (
-	"fmt"
 	"time"
-)
 
-func MostrarRutaOptimizada() {
-	fmt.Print("\033[H\033[2J")
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+)
 
-	verde := "\033[32m"
-	reset := "\033[0m"
-	titulo := verde + "[Ruta Optimizada de Pallet]" + reset
+func MostrarRutaOptimizada() error {
+	output.ClearScreen()
 
-	fmt.Println("\n" + titulo)
-	fmt.Println("\nAquí iría la implementación para mostrar la ruta optimizada.")
+	output.Title(i18n.T("route.title"))
+	output.Println("\n" + i18n.T("route.placeholder"))
 
-	fmt.Println("\nAnalizando rutas posibles...")
+	output.Println("\n" + i18n.T("route.analyzing"))
 	time.Sleep(1 * time.Second)
-	fmt.Println("Calculando distancias...")
+	output.Println(i18n.T("route.distances"))
 	time.Sleep(1 * time.Second)
-	fmt.Println("Optimización completada. La mejor ruta es: A → C → B → D")
+	output.Println(i18n.T("route.done"))
 
-	fmt.Println("\nPresiona Enter para volver al menú principal...")
-	fmt.Scanln()
+	return nil
 }
 
-func CorregirXY() {
-	fmt.Print("\033[H\033[2J")
+func CorregirXY() error {
+	output.ClearScreen()
 
-	verde := "\033[32m"
-	reset := "\033[0m"
-	titulo := verde + "[Corrección de X&Y]" + reset
+	output.Title(i18n.T("xy.title"))
+	output.Println("\n" + i18n.T("xy.placeholder"))
 
-	fmt.Println("\n" + titulo)
-	fmt.Println("\nAquí iría la implementación de la corrección de coordenadas.")
-
-	fmt.Println("\nProcesando coordenadas...")
+	output.Println("\n" + i18n.T("xy.processing"))
 	time.Sleep(1 * time.Second)
-	fmt.Println("Conectando a Google Places API...")
+	output.Println(i18n.T("xy.connecting"))
 	time.Sleep(1 * time.Second)
-	fmt.Println("Coordenadas corregidas correctamente.")
+	output.Println(i18n.T("xy.done"))
 
-	fmt.Println("\nPresiona Enter para volver al menú principal...")
-	fmt.Scanln()
+	return nil
 }
 
-func MostrarAyuda() {
-	fmt.Print("\033[H\033[2J")
-
-	verde := "\033[32m"
-	reset := "\033[0m"
-	titulo := verde + "[Ayuda]" + reset
+func MostrarAyuda() error {
+	output.ClearScreen()
 
-	fmt.Println("\n" + titulo)
-	fmt.Println("\nEsta aplicación CLI permite realizar diversas tareas relacionadas con la gestión de coordenadas y rutas.")
-	fmt.Println("\nOpciones disponibles:")
-	fmt.Println("- Corregir X&Y: Herramienta para ajustar coordenadas usando Google Places")
-	fmt.Println("- Mostrar ruta optimizada: Calcula la mejor ruta para un pallet")
-	fmt.Println("- Obtener coordenadas: Extrae coordenadas de un pallet y las guarda en un archivo")
-	fmt.Println("- Generar mapa HTML: Crea un mapa interactivo a partir de un archivo de coordenadas")
-	fmt.Println("- Ayuda: Muestra esta información")
+	output.Title(i18n.T("help.title"))
+	output.Println("\n" + i18n.T("help.intro"))
+	output.Println("\n" + i18n.T("help.options"))
+	output.Println(i18n.T("help.option.xy"))
+	output.Println(i18n.T("help.option.route"))
+	output.Println(i18n.T("help.option.coords"))
+	output.Println(i18n.T("help.option.map"))
+	output.Println(i18n.T("help.option.help"))
 
-	fmt.Println("\nInstrucciones de uso:")
-	fmt.Println("1. Usa las flechas ↑/↓ para navegar por el menú")
-	fmt.Println("2. Presiona Enter para seleccionar una opción")
-	fmt.Println("3. En cualquier momento puedes presionar q para salir")
+	output.Println("\n" + i18n.T("help.usage"))
+	output.Println(i18n.T("help.usage.navigate"))
+	output.Println(i18n.T("help.usage.select"))
+	output.Println(i18n.T("help.usage.quit"))
 
-	fmt.Println("\nPresiona Enter para volver al menú principal...")
-	fmt.Scanln()
+	return nil
 }
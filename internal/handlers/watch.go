@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
+	"github.com/Cait-dev/alas-tools-cli/internal/pallet"
+)
+
+// MinWatchInterval evita consultar la API con demasiada frecuencia.
+const MinWatchInterval = 10 * time.Second
+
+// VigilarPallets consulta los pallets cada interval y, cuando sus órdenes
+// cambian, vuelve a escribir las exportaciones y el mapa HTML. En cada ciclo
+// imprime un registro breve de los cambios. Termina con Ctrl+C.
+func VigilarPallets(palletInput string, interval time.Duration, opts CoordsOptions) error {
+	output.ClearScreen()
+	output.Title(i18n.T("watch.title"))
+
+	codes, err := pallet.ParseList(palletInput)
+	if err != nil {
+		return apperr.New(apperr.KindUsage, i18n.T("pallet.invalid", err))
+	}
+	if len(codes) == 0 {
+		return apperr.New(apperr.KindUsage, i18n.T("coords.no_pallets"))
+	}
+	if interval < MinWatchInterval {
+		return apperr.New(apperr.KindUsage, i18n.T("watch.interval_too_short", MinWatchInterval))
+	}
+
+	opts.Map = true
+	opts.JSON = false
+
+	// Los archivos se nombran una sola vez para que cada ciclo los reescriba
+	grupos := [][]string{codes}
+	if len(codes) > 1 {
+		for _, code := range codes {
+			grupos = append(grupos, []string{code})
+		}
+	}
+	opts.baseNames, err = resolveBaseNames(grupos, opts.Output)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	output.Println("\n" + i18n.T("watch.start", strings.Join(codes, ", "), interval))
+	slog.Info("vigilancia iniciada", "pallets", codes, "interval", interval.String())
+
+	var anterior []coords.SnapshotOrder
+	var pendiente pendingWrites
+	primero := true
+	for {
+		// Si la consulta falla se mantiene la anterior para comparar con ella
+		// en el próximo ciclo
+		actual, escrituras, err := watchCycle(codes, anterior, primero, pendiente, opts)
+		if kind := apperr.KindOf(err); kind == apperr.KindAuth || kind == apperr.KindUsage {
+			return err
+		}
+		if err == nil {
+			anterior = actual
+			pendiente = escrituras
+			primero = false
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("vigilancia terminada", "pallets", codes)
+			output.Println("\n" + i18n.T("watch.stopped"))
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pendingWrites son las escrituras que fallaron en un ciclo y se reintentan
+// en el siguiente aunque los pallets no cambien. Si active es true y steps es
+// nil se reintentan todas.
+type pendingWrites struct {
+	active bool
+	steps  map[string]bool
+}
+
+// watchCycle consulta los pallets, compara con la consulta anterior y
+// reescribe los archivos si hubo cambios, o solo los que fallaron en el ciclo
+// anterior si no los hubo. Devuelve las órdenes actuales y las escrituras
+// pendientes, o un error si no se pudo consultar la API; los errores que no
+// terminan la vigilancia ya quedan registrados.
+func watchCycle(codes []string, anterior []coords.SnapshotOrder, primero bool, pendiente pendingWrites, opts CoordsOptions) ([]coords.SnapshotOrder, pendingWrites, error) {
+	output.SetQuiet(true)
+	responseData, err := fetchDeliveryOrders(codes)
+	output.SetQuiet(false)
+	if apperr.KindOf(err) == apperr.KindNotFound {
+		err = nil
+	}
+	if err != nil {
+		if kind := apperr.KindOf(err); kind != apperr.KindAuth && kind != apperr.KindUsage {
+			slog.Warn("error en ciclo de vigilancia", "error", err)
+			logLine(i18n.T("watch.cycle_error", err))
+		}
+		return nil, pendiente, err
+	}
+	actual := snapshotFromResponse(responseData)
+
+	var retry map[string]bool
+	if primero {
+		logLine(i18n.T("watch.initial", len(actual)))
+	} else {
+		diff := coords.CompareSnapshots(anterior, actual)
+		switch {
+		case !diff.Empty():
+			slog.Info("cambios detectados", "pallets", codes, "added", len(diff.Added), "removed", len(diff.Removed), "moved", len(diff.Moved), "resequenced", len(diff.Resequenced))
+			logLine(i18n.T("watch.changes", len(diff.Added), len(diff.Removed), len(diff.Moved), len(diff.Resequenced)))
+			printDiff(diff)
+		case pendiente.active:
+			logLine(i18n.T("watch.retrying", len(actual)))
+			retry = pendiente.steps
+		default:
+			logLine(i18n.T("watch.no_changes", len(actual)))
+			return actual, pendingWrites{}, nil
+		}
+	}
+
+	if len(responseData.Items) == 0 {
+		logLine(i18n.T("watch.empty"))
+		return actual, pendingWrites{}, nil
+	}
+	escrituras := rewriteFiles(codes, responseData, retry, opts)
+	if !escrituras.active {
+		logLine(i18n.T("watch.rewritten", opts.baseNames[strings.Join(codes, ",")]))
+	}
+	return actual, escrituras, nil
+}
+
+// rewriteFiles vuelve a generar los archivos, o solo los pasos de retry si no
+// es nil, sin mostrar el detalle de la extracción; solo informa si algo
+// falló. Devuelve las escrituras que quedan pendientes.
+func rewriteFiles(codes []string, responseData models.DeliveryOrderResponse, retry map[string]bool, opts CoordsOptions) pendingWrites {
+	opts.retry = retry
+	output.SetQuiet(true)
+	result, err := procesarOrdenes(codes, responseData, opts)
+	output.SetQuiet(false)
+	if err == nil {
+		return pendingWrites{}
+	}
+
+	slog.Warn("error al reescribir los archivos", "pallets", codes, "error", err)
+	logLine(i18n.T("watch.rewrite_error", err))
+	// Sin coordenadas válidas no hay nada que reintentar hasta que cambien
+	if apperr.KindOf(err) == apperr.KindNotFound {
+		return pendingWrites{}
+	}
+	// Si la extracción se detuvo antes de los pasos que se reintentan por
+	// separado, se reintenta todo
+	if result == nil || len(result.failed) == 0 {
+		return pendingWrites{active: true}
+	}
+	pasos := map[string]bool{}
+	for _, paso := range result.failed {
+		pasos[paso] = true
+	}
+	return pendingWrites{active: true, steps: pasos}
+}
+
+func logLine(message string) {
+	output.Println(fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), message))
+}
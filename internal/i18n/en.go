@@ -0,0 +1,340 @@
+package i18n
+
+var en = map[string]string{
+	// General messages
+	"main.version":        "Alas-Tools-Cli version %s",
+	"main.error":          "Error: %v",
+	"main.needs_terminal": "The interactive menu requires a terminal. Use a subcommand:",
+	"main.goodbye":        "See you soon! Thanks for using Alas-Tools-Cli.",
+
+	"output.error":       "[ERROR]",
+	"output.success":     "[SUCCESS]",
+	"output.warning":     "[WARNING]",
+	"output.pause":       "Press Enter to return to the main menu...",
+	"output.confirm":     "(y/n)",
+	"output.confirm_yes": "y,yes",
+
+	"config.env_error":        "Error reading the .env file: %v",
+	"config.missing_user":     "Warning: ALAS_API_USER is not set, using the development default",
+	"config.missing_password": "Warning: ALAS_API_PASSWORD is not set, using the development default",
+
+	"log.err_level":   "invalid log level: %s (options: debug, info, warn, error)",
+	"log.err_format":  "invalid log format: %s (options: text, json)",
+	"log.setup_error": "Could not open the log file: %v",
+
+	"api.err_request":  "error creating the request",
+	"api.err_connect":  "error connecting to the API",
+	"api.err_response": "error reading the response",
+	"api.err_auth":     "the API rejected the credentials (status %d); check ALAS_API_USER and ALAS_API_PASSWORD",
+	"api.err_status":   "status code: %d - %s",
+
+	// Main menu
+	"menu.title":          "Main Menu",
+	"menu.xy.title":       "Option 1: Fix X&Y",
+	"menu.xy.desc":        "Tool to fix coordinates using Google Places",
+	"menu.route.title":    "Option 2: Show an optimized route for a pallet",
+	"menu.route.desc":     "Compares the routes",
+	"menu.coords.title":   "Option 3: Get coordinates",
+	"menu.coords.desc":    "Extracts the coordinates of a pallet and saves them to a file",
+	"menu.map.title":      "Option 4: Generate HTML map",
+	"menu.map.desc":       "Builds an interactive map from a coordinates file",
+	"menu.help.title":     "Option 5: Help",
+	"menu.help.desc":      "Shows the help information",
+	"menu.quit.title":     "Quit",
+	"menu.quit.desc":      "Quit the application",
+	"menu.thanks":         "Thanks for using Alas-Tools-Cli!",
+	"menu.start_error":    "Error starting the application: %v",
+	"menu.returning":      "Returning to the main menu...",
+	"splash.welcome":      "Welcome to Alas-Tools-Cli v1.1.1",
+	"splash.instructions": "Use the arrow keys to navigate: ↑ ↓",
+
+	// Get coordinates
+	"coords.title":               "Get Coordinates",
+	"coords.intro":               "This tool extracts the coordinates of the orders assigned to a pallet.",
+	"coords.prompt":              "Enter the pallet code (e.g. pl202505danl001), several separated by commas or a range (e.g. pl202505danl001..010): ",
+	"coords.no_pallets":          "You must enter at least one valid pallet code.",
+	"coords.querying":            "Querying the API for %d pallet(s): %s...",
+	"coords.parse_error":         "Error processing the response: %v",
+	"coords.no_orders":           "No orders were found for the given pallets.",
+	"coords.found_orders":        "Found a total of %d orders. Fetching coordinates...",
+	"coords.no_valid":            "No valid coordinates were found for the given pallets.",
+	"coords.history_error":       "Could not save the pallet history: %v",
+	"coords.profile_error":       "Could not save the profile history: %v",
+	"coords.write_error":         "Error writing the file: %v",
+	"coords.write_clean_error":   "Error writing the clean file: %v",
+	"coords.success":             "Found %d coordinates sorted by Vehicle Location.",
+	"coords.file_created":        "Created %s with the coordinates in the requested format.",
+	"coords.clean_created":       "Also created %s in a format compatible with other tools.",
+	"coords.missing_geolocation": "%d of %d orders have no geolocation and were left out. They were saved to %s for X&Y correction.",
+	"coords.ask_map":             "Do you want to generate an HTML map with these coordinates?",
+	"coords.pallet_files":        "Pallet %s (%d stops): %s",
+	"coords.pallet_write_error":  "Could not write the files for pallet %s: %v",
+	"coords.index_created":       "Created the pallet index %s.",
+	"coords.index_error":         "Could not write the pallet index: %v",
+
+	// Output files
+	"outfile.unsupported_policy": "unsupported overwrite policy: %v",
+	"outfile.invalid_template":   "invalid filename template: %v",
+	"outfile.exists":             "Files %s.* already exist. Do you want to overwrite them? (answering no uses a new name)",
+	"outfile.exists_fail":        "files %s.* already exist (use --overwrite suffix or overwrite)",
+	"outfile.renamed":            "Files %s.* already exist; the new ones will be saved as %s.*",
+	"outfile.mkdir_error":        "Could not create the output directory %s: %v",
+
+	// Exports
+	"export.unsupported":          "unsupported export format: %v",
+	"export.write_error":          "Could not export in %s format: %v",
+	"export.file_created":         "Exported the coordinates to %s.",
+	"export.unsupported_column":   "unsupported export column: %v",
+	"export.sheet_default":        "Orders",
+	"export.route_name":           "Route %s",
+	"export.col.pallet":           "Pallet",
+	"export.col.sequence":         "Sequence",
+	"export.col.vehicle_location": "Vehicle Location",
+	"export.col.order_id":         "Order ID",
+	"export.col.orders":           "Orders",
+	"export.col.tracking_code":    "Tracking Code",
+	"export.col.customer_name":    "Customer",
+	"export.col.issue":            "Issue",
+	"export.col.address":          "Address",
+	"export.col.commune":          "Commune",
+	"export.col.lat":              "Latitude",
+	"export.col.lon":              "Longitude",
+	"export.col.flags":            "Flags",
+
+	// Pallet codes
+	"pallet.invalid":            "invalid pallet codes:\n%v",
+	"pallet.err_empty":          "empty code",
+	"pallet.err_prefix":         "must start with %q",
+	"pallet.err_year_month":     "invalid year and month %q, 6 digits YYYYMM expected after the prefix (e.g. 202505)",
+	"pallet.err_warehouse":      "missing warehouse code (letters, e.g. danl) in %q",
+	"pallet.err_sequence":       "invalid sequence %q, up to %d digits expected at the end (e.g. 001)",
+	"pallet.err_trailing":       "unexpected characters %q after the sequence",
+	"pallet.err_range_order":    "the end of the range is lower than the start",
+	"pallet.err_range_size":     "a range cannot span more than %d pallets",
+	"pallet.err_range_mismatch": "the start and end of the range must have the same year, month and warehouse",
+
+	// Coordinate validation
+	"validate.region_error":      "invalid validation region: %v",
+	"validate.no_polygons":       "%s contains no polygons",
+	"validate.flagged":           "%d orders flagged: %s (%s).",
+	"flag.invalid_range":         "Coordinates out of range",
+	"flag.swapped_axes":          "Latitude and longitude probably swapped",
+	"flag.sign_error":            "Latitude or longitude sign probably wrong",
+	"flag.out_of_region":         "Outside the expected region",
+	"flag.outlier":               "Stop far from the rest of the pallet",
+	"outliers.unsupported":       "unsupported outlier detection method: %v",
+	"consolidate.invalid_radius": "invalid consolidation radius: %s (must be a number of meters greater than or equal to 0)",
+	"consolidate.summary":        "%d orders were placed in %d stops: %d stops group orders less than %.0f m apart.",
+	"outliers.detail":            "Order %s (pallet %s, Vehicle Location %d): %.1f km from the pallet center",
+
+	// Vehicle location sequence
+	"sequence.duplicated":            "%s: duplicated vehicle_location values: %s.",
+	"sequence.missing":               "%s: missing vehicle_location values: %s.",
+	"sequence.zero":                  "%s: %d orders with vehicle_location zero.",
+	"sequence.multiple_pallets":      "%s: orders in more than one pallet: %s.",
+	"sequence.report":                "%d vehicle_location issues were saved to %s.",
+	"sequence.all_pallets":           "All pallets",
+	"sequence.kind.duplicated":       "Duplicated",
+	"sequence.kind.missing":          "Missing",
+	"sequence.kind.zero":             "Zero",
+	"sequence.kind.multiple_pallets": "In several pallets",
+
+	// Route statistics
+	"stats.route":            "Route %s: %d stops, %.1f km (longest leg %.1f km, average %.1f km)",
+	"stats.area":             "Centroid (%.5f, %.5f), hull of %.2f km², %.1f stops/km²",
+	"stats.bbox":             "Bounds: (%.5f, %.5f) to (%.5f, %.5f)",
+	"stats.file_created":     "Route statistics were saved to %s.",
+	"stats.write_error":      "Could not save the route statistics: %v",
+	"stats.col.stops":        "Stops",
+	"stats.col.length":       "Length (km)",
+	"stats.col.longest_leg":  "Longest leg (km)",
+	"stats.col.avg_leg":      "Average leg (km)",
+	"stats.col.min_lat":      "Minimum latitude",
+	"stats.col.min_lon":      "Minimum longitude",
+	"stats.col.max_lat":      "Maximum latitude",
+	"stats.col.max_lon":      "Maximum longitude",
+	"stats.col.centroid_lat": "Centroid latitude",
+	"stats.col.centroid_lon": "Centroid longitude",
+	"stats.col.hull_area":    "Hull area (km²)",
+	"stats.col.density":      "Stops per km²",
+	"index.col.orders":       "Orders",
+	"index.col.missing":      "Without geolocation",
+	"index.col.files":        "Files",
+
+	// Compare extractions
+	"diff.title":                  "Compare Coordinates",
+	"diff.live":                   "API (live)",
+	"diff.summary":                "Before: %s (%d orders). After: %s (%d orders).",
+	"diff.no_changes":             "There are no differences between the extractions.",
+	"diff.no_pallets":             "%s does not list the pallets to query the API; provide a second file",
+	"diff.added":                  "Added orders (%d):",
+	"diff.removed":                "Removed orders (%d):",
+	"diff.moved":                  "Moved coordinates (%d):",
+	"diff.resequenced":            "Changed vehicle locations (%d):",
+	"diff.order":                  "%s (pallet %s, Vehicle Location %d)",
+	"diff.moved_order":            "%s (pallet %s): moved %.0f m",
+	"diff.geolocated_order":       "%s (pallet %s): geolocated",
+	"diff.lost_geolocation_order": "%s (pallet %s): lost its geolocation",
+	"diff.resequenced_order":      "%s (pallet %s): %d -> %d",
+	"diff.not_found":              "The file %s does not exist.",
+	"diff.err_read":               "Error reading %s",
+	"diff.err_format":             "%s is not a valid extraction: %v",
+	"diff.unknown_format":         "the coords --json summary or a GeoJSON export is expected (.txt files do not identify every order)",
+	"diff.no_order_id":            "it includes stops without an order ID",
+	"diff.html.title":             "Differences between extractions",
+	"diff.html.order":             "Order",
+	"diff.html.distance":          "Distance moved",
+	"diff.html.unchanged":         "Unchanged",
+	"diff.html.added":             "Added",
+	"diff.html.removed":           "Removed",
+	"diff.html.moved":             "Moved",
+	"diff.html.resequenced":       "Vehicle location changed",
+	"diff.html.geolocated":        "Geolocated",
+	"diff.html.lost_geolocation":  "Lost geolocation",
+
+	// Compare sequences
+	"compare.title":             "Compare Sequences",
+	"compare.summary":           "Plan: %s (%d stops, %.1f km). Alternative: %s (%d stops, %.1f km).",
+	"compare.difference":        "Alternative difference: %+.1f km (%+.1f%%).",
+	"compare.by_coordinates":    "Stops were matched by coordinates because not all of them include their order.",
+	"compare.no_changes":        "Both sequences visit the same stops in the same order.",
+	"compare.changed":           "Stops that change position (%d):",
+	"compare.stop":              "%s: %d -> %d",
+	"compare.only_planned":      "Only in the plan (%d):",
+	"compare.only_alternative":  "Only in the alternative (%d):",
+	"compare.html.title":        "Plan vs. alternative sequence",
+	"compare.html.planned":      "Plan",
+	"compare.html.alternative":  "Alternative",
+	"compare.html.difference":   "Difference",
+	"compare.html.only_changed": "Show only changed stops",
+	"compare.html.stop":         "Stop",
+	"compare.html.shift":        "Position change",
+
+	// Watch pallets
+	"watch.title":              "Watch Pallets",
+	"watch.start":              "Watching %s every %s. Press Ctrl+C to stop.",
+	"watch.initial":            "Initial extraction: %d orders.",
+	"watch.no_changes":         "No changes (%d orders).",
+	"watch.changes":            "Changes: %d added, %d removed, %d moved, %d with changed vehicle_location.",
+	"watch.rewritten":          "Files updated: %s.*",
+	"watch.empty":              "The pallets have no orders; the files were not rewritten.",
+	"watch.rewrite_error":      "The files could not be rewritten: %v",
+	"watch.retrying":           "No changes (%d orders); retrying the files that could not be written.",
+	"watch.cycle_error":        "Error querying the API: %v",
+	"watch.stopped":            "Watch stopped.",
+	"watch.interval_too_short": "the interval must be at least %s",
+
+	// Generate HTML map
+	"map.title":               "Generate HTML Map",
+	"map.intro":               "This tool generates an interactive HTML map from a coordinates, CSV, GeoJSON, KML or GPX file.",
+	"map.prompt":              "Enter the path of the coordinates file (e.g. coordenadas_pl202505danl001.txt): ",
+	"map.no_file":             "You must provide a coordinates file.",
+	"map.not_found":           "The specified file does not exist: %s",
+	"map.no_coords":           "No valid coordinates could be extracted from the file.",
+	"map.success":             "Generated the map with %d points.",
+	"map.file_created":        "HTML file created: %s",
+	"map.open_hint":           "You can open this file in any browser to see the interactive map.",
+	"map.err_read":            "error reading the file",
+	"map.err_parse":           "Could not interpret the file %s:\n%v",
+	"map.err_template":        "error processing the template",
+	"map.err_create":          "error creating the HTML file",
+	"map.err_render":          "error rendering the HTML",
+	"map.offline_missing":     "This binary does not include the Leaflet files for offline maps.\nDownload them with \"go generate ./internal/mapassets\" and rebuild, or generate the map without --offline.",
+	"map.html.title":          "Coordinates Map",
+	"map.html.toggle":         "Show/Hide Line",
+	"map.html.fit":            "Fit View",
+	"map.html.orders":         "Orders",
+	"map.html.point":          "Point",
+	"map.html.order":          "Order",
+	"map.html.tracking":       "Tracking",
+	"map.html.customer":       "Customer",
+	"map.html.address":        "Address",
+	"map.html.search":         "Search order, tracking, customer or address",
+	"map.html.stops":          "stops",
+	"map.html.order_count":    "orders",
+	"map.html.legend":         "Pallets",
+	"map.html.route":          "Route",
+	"map.html.solo":           "Only",
+	"map.html.show_all":       "Show all",
+	"map.html.leaflet_failed": "The map library (Leaflet) could not be loaded. Check your internet connection or generate the map with --offline.",
+	"map.html.tiles_failed":   "The base map could not be loaded. Points and routes are still shown.",
+
+	// Reading coordinate files
+	"parse.position":         "line %d, column %d: %s",
+	"parse.number":           "number",
+	"parse.end":              "end of file",
+	"parse.expected":         "expected %s but found %s",
+	"parse.unexpected":       "unexpected %s",
+	"parse.unexpected_text":  "unexpected text %q",
+	"parse.invalid_number":   "invalid number %q",
+	"parse.unclosed_comment": "unclosed comment",
+	"parse.trailing":         "content after \"]\": %s",
+	"parse.more_errors":      "and %d more errors",
+
+	// Importing stops for the map
+	"import.unsupported_format":   "unsupported input format: %v",
+	"import.invalid_columns":      "invalid column mapping: %v",
+	"import.csv_empty":            "the CSV file is empty",
+	"import.csv_row":              "row %d: %s",
+	"import.invalid_coordinates":  "invalid coordinates (%q, %q)",
+	"import.csv_column_not_found": "column %q was not found (columns: %s)",
+	"import.csv_missing_column":   "could not find the %s column; set it with --columns (columns: %s)",
+	"import.geojson_type":         "unsupported GeoJSON type %q, expected FeatureCollection or Feature",
+	"import.geojson_point":        "feature %d: the point must have longitude and latitude",
+	"import.xml_point":            "line %d: invalid coordinates %q",
+
+	// Optimized route, X&Y correction and help
+	"route.title":       "Optimized Pallet Route",
+	"route.placeholder": "The optimized route implementation would go here.",
+	"route.analyzing":   "Analyzing possible routes...",
+	"route.distances":   "Computing distances...",
+	"route.done":        "Optimization complete. The best route is: A → C → B → D",
+
+	"xy.title":       "X&Y Correction",
+	"xy.placeholder": "The coordinate correction implementation would go here.",
+	"xy.processing":  "Processing coordinates...",
+	"xy.connecting":  "Connecting to the Google Places API...",
+	"xy.done":        "Coordinates fixed successfully.",
+
+	"help.title":          "Help",
+	"help.intro":          "This CLI application performs several tasks related to coordinate and route management.",
+	"help.options":        "Available options:",
+	"help.option.xy":      "- Fix X&Y: Tool to adjust coordinates using Google Places",
+	"help.option.route":   "- Show optimized route: Computes the best route for a pallet",
+	"help.option.coords":  "- Get coordinates: Extracts the coordinates of a pallet and saves them to a file",
+	"help.option.map":     "- Generate HTML map: Builds an interactive map from a coordinates file",
+	"help.option.help":    "- Help: Shows this information",
+	"help.usage":          "Usage instructions:",
+	"help.usage.navigate": "1. Use the ↑/↓ arrows to move through the menu",
+	"help.usage.select":   "2. Press Enter to select an option",
+	"help.usage.quit":     "3. Press q at any time to quit",
+
+	// Subcommands
+	"cli.unknown_command":    "unknown command: %s (use '%s help' to list the available commands)",
+	"cli.usage":              "usage: %s %s",
+	"cli.help.usage":         "Usage: %s [options] [command] [arguments]",
+	"cli.help.menu":          "Without arguments the interactive menu is opened.",
+	"cli.help.commands":      "Commands:",
+	"cli.help.options":       "Options:",
+	"cli.usage.coords":       "coords [--export geojson,csv,xlsx,kml,gpx] [--route] [--columns <columns>] [--json] [--region <region>] [--outliers mad|iqr|dbscan|off] [--consolidate <meters>] [--map] [--offline] [--output-dir <directory>] [--name <template>] [--daily] [--overwrite prompt|suffix|overwrite|fail] [--profile <profile>] <pallet>[,<pallet>...]",
+	"cli.usage.coords_diff":  "coords diff [--map <file.html>] [--offline] [--json] <before> [<after>]",
+	"cli.usage.coords_watch": "coords watch --pallet <pallet> [--interval 2m] [--export <formats>] [...]",
+	"cli.usage.map":          "map [--format auto|text|csv|geojson|kml|gpx] [--columns lat=<column>,lon=<column>,...] [--offline] <file>",
+	"cli.usage.map_compare":  "map compare [--format <format>] [--columns <columns>] [--map <file.html>] [--offline] [--json] <plan> <alternative>",
+	"cli.usage.completion":   "completion bash|zsh|fish",
+	"cli.usage.help":         "help",
+	"cli.cmd.coords":         "Extracts the coordinates of one or more pallets and saves them to a file",
+	"cli.cmd.coords_diff":    "Compares two extractions (--json summary or GeoJSON), or a saved extraction with the API when <after> is omitted",
+	"cli.cmd.coords_watch":   "Polls the pallets at an interval and rewrites the exports and map when they change (Ctrl+C to stop)",
+	"cli.cmd.map":            "Generates an HTML map from a coordinates, CSV, GeoJSON, KML or GPX file",
+	"cli.cmd.map_compare":    "Overlays the planned sequence and an alternative one (optimized or edited) on a map, with the distance of each and the position changes",
+	"cli.cmd.completion":     "Generates the completion script for the given shell",
+	"cli.cmd.help":           "Lists the available commands",
+	"cli.opt.version":        "Shows the version",
+	"cli.opt.no_color":       "Disables colors (also with NO_COLOR)",
+	"cli.opt.plain":          "Output without colors, screen clears or pauses",
+	"cli.opt.log_level":      "Log verbosity (default info)",
+	"cli.opt.log_format":     "Log format; the log is written to %s",
+	"cli.unsupported_shell":  "unsupported shell: %s (options: bash, zsh, fish)",
+	"cli.completion.command": "command",
+}
@@ -0,0 +1,340 @@
+package i18n
+
+var es = map[string]string{
+	// Mensajes generales
+	"main.version":        "Alas-Tools-Cli versión %s",
+	"main.error":          "Error: %v",
+	"main.needs_terminal": "El menú interactivo requiere una terminal. Use un subcomando:",
+	"main.goodbye":        "¡Hasta pronto! Gracias por usar Alas-Tools-Cli.",
+
+	"output.error":       "[ERROR]",
+	"output.success":     "[ÉXITO]",
+	"output.warning":     "[AVISO]",
+	"output.pause":       "Presiona Enter para volver al menú principal...",
+	"output.confirm":     "(s/n)",
+	"output.confirm_yes": "s,si,sí",
+
+	"config.env_error":        "Error al leer el archivo .env: %v",
+	"config.missing_user":     "Advertencia: ALAS_API_USER no está configurada, usando valor predeterminado para desarrollo",
+	"config.missing_password": "Advertencia: ALAS_API_PASSWORD no está configurada, usando valor predeterminado para desarrollo",
+
+	"log.err_level":   "nivel de log no válido: %s (opciones: debug, info, warn, error)",
+	"log.err_format":  "formato de log no válido: %s (opciones: text, json)",
+	"log.setup_error": "No se pudo abrir el archivo de log: %v",
+
+	"api.err_request":  "error al crear la petición",
+	"api.err_connect":  "error al conectar con la API",
+	"api.err_response": "error al leer la respuesta",
+	"api.err_auth":     "credenciales rechazadas por la API (código %d); revise ALAS_API_USER y ALAS_API_PASSWORD",
+	"api.err_status":   "código de estado: %d - %s",
+
+	// Menú principal
+	"menu.title":          "Menú Principal",
+	"menu.xy.title":       "Opción 1: Corregir X&Y",
+	"menu.xy.desc":        "Herramienta para corregir coordenadas usando Google Places",
+	"menu.route.title":    "Opción 2: Mostrar una ruta optimizada de un pallet",
+	"menu.route.desc":     "Compara las rutas",
+	"menu.coords.title":   "Opción 3: Obtener coordenadas",
+	"menu.coords.desc":    "Extrae coordenadas de un pallet y las guarda en un archivo",
+	"menu.map.title":      "Opción 4: Generar mapa HTML",
+	"menu.map.desc":       "Crea un mapa interactivo a partir de un archivo de coordenadas",
+	"menu.help.title":     "Opción 5: Ayuda",
+	"menu.help.desc":      "Muestra la información de ayuda",
+	"menu.quit.title":     "Salir",
+	"menu.quit.desc":      "Salir de la aplicación",
+	"menu.thanks":         "¡Gracias por usar Alas-Tools-Cli!",
+	"menu.start_error":    "Error al iniciar la aplicación: %v",
+	"menu.returning":      "Volviendo al menú principal...",
+	"splash.welcome":      "Bienvenido a Alas-Tools-Cli v1.1.1",
+	"splash.instructions": "Usa las flechas para navegar: ↑ ↓",
+
+	// Obtener coordenadas
+	"coords.title":               "Obtener Coordenadas",
+	"coords.intro":               "Esta herramienta extrae coordenadas de las órdenes asociadas a un pallet.",
+	"coords.prompt":              "Ingrese el código de pallet (ej. pl202505danl001), varios separados por comas o un rango (ej. pl202505danl001..010): ",
+	"coords.no_pallets":          "Debe ingresar al menos un código de pallet válido.",
+	"coords.querying":            "Consultando API para %d pallet(s): %s...",
+	"coords.parse_error":         "Error al procesar la respuesta: %v",
+	"coords.no_orders":           "No se encontraron órdenes para los pallets proporcionados.",
+	"coords.found_orders":        "Se encontraron un total de %d órdenes. Obteniendo coordenadas...",
+	"coords.no_valid":            "No se encontraron coordenadas válidas para los pallets proporcionados.",
+	"coords.history_error":       "No se pudo guardar el historial de pallets: %v",
+	"coords.profile_error":       "No se pudo guardar el historial de perfiles: %v",
+	"coords.write_error":         "Error al escribir el archivo: %v",
+	"coords.write_clean_error":   "Error al escribir el archivo limpio: %v",
+	"coords.success":             "Se encontraron %d coordenadas ordenadas por Vehicle Location.",
+	"coords.file_created":        "Se ha creado el archivo %s con las coordenadas en el formato solicitado.",
+	"coords.clean_created":       "También se creó %s con un formato compatible para otras herramientas.",
+	"coords.missing_geolocation": "%d de %d órdenes no tienen geolocalización y no se incluyeron. Se guardaron en %s para su corrección de X&Y.",
+	"coords.ask_map":             "¿Desea generar un mapa HTML con estas coordenadas?",
+	"coords.pallet_files":        "Pallet %s (%d paradas): %s",
+	"coords.pallet_write_error":  "No se pudieron escribir los archivos del pallet %s: %v",
+	"coords.index_created":       "Se creó el índice de pallets %s.",
+	"coords.index_error":         "No se pudo escribir el índice de pallets: %v",
+
+	// Archivos de salida
+	"outfile.unsupported_policy": "política de sobrescritura no soportada: %v",
+	"outfile.invalid_template":   "plantilla de nombre inválida: %v",
+	"outfile.exists":             "Ya existen archivos %s.*. ¿Desea sobrescribirlos? (si responde no, se usará un nombre nuevo)",
+	"outfile.exists_fail":        "ya existen archivos %s.* (use --overwrite suffix u overwrite)",
+	"outfile.renamed":            "Ya existen archivos %s.*; los nuevos se guardarán como %s.*",
+	"outfile.mkdir_error":        "No se pudo crear el directorio de salida %s: %v",
+
+	// Exportaciones
+	"export.unsupported":          "formato de exportación no soportado: %v",
+	"export.write_error":          "No se pudo exportar en formato %s: %v",
+	"export.file_created":         "Se exportaron las coordenadas a %s.",
+	"export.unsupported_column":   "columna de exportación no soportada: %v",
+	"export.sheet_default":        "Órdenes",
+	"export.route_name":           "Ruta %s",
+	"export.col.pallet":           "Pallet",
+	"export.col.sequence":         "Secuencia",
+	"export.col.vehicle_location": "Vehicle Location",
+	"export.col.order_id":         "ID Orden",
+	"export.col.orders":           "Órdenes",
+	"export.col.tracking_code":    "Código de seguimiento",
+	"export.col.customer_name":    "Cliente",
+	"export.col.issue":            "Problema",
+	"export.col.address":          "Dirección",
+	"export.col.commune":          "Comuna",
+	"export.col.lat":              "Latitud",
+	"export.col.lon":              "Longitud",
+	"export.col.flags":            "Observaciones",
+
+	// Códigos de pallet
+	"pallet.invalid":            "códigos de pallet inválidos:\n%v",
+	"pallet.err_empty":          "código vacío",
+	"pallet.err_prefix":         "debe comenzar con %q",
+	"pallet.err_year_month":     "año y mes inválidos %q, se esperan 6 dígitos AAAAMM después del prefijo (ej. 202505)",
+	"pallet.err_warehouse":      "falta el código de bodega (letras, ej. danl) en %q",
+	"pallet.err_sequence":       "secuencia inválida %q, se esperan hasta %d dígitos al final (ej. 001)",
+	"pallet.err_trailing":       "caracteres sobrantes %q después de la secuencia",
+	"pallet.err_range_order":    "el final del rango es menor que el inicio",
+	"pallet.err_range_size":     "el rango no puede abarcar más de %d pallets",
+	"pallet.err_range_mismatch": "el inicio y el final del rango deben tener el mismo año, mes y bodega",
+
+	// Validación de coordenadas
+	"validate.region_error":      "región de validación no válida: %v",
+	"validate.no_polygons":       "%s no contiene polígonos",
+	"validate.flagged":           "%d órdenes marcadas: %s (%s).",
+	"flag.invalid_range":         "Coordenadas fuera de rango",
+	"flag.swapped_axes":          "Latitud y longitud probablemente invertidas",
+	"flag.sign_error":            "Signo de latitud o longitud probablemente incorrecto",
+	"flag.out_of_region":         "Fuera de la región esperada",
+	"flag.outlier":               "Parada alejada del resto del pallet",
+	"outliers.unsupported":       "método de detección de paradas atípicas no soportado: %v",
+	"consolidate.invalid_radius": "radio de consolidación no válido: %s (debe ser un número de metros mayor o igual a 0)",
+	"consolidate.summary":        "%d órdenes quedaron en %d paradas: %d paradas agrupan órdenes a menos de %.0f m.",
+	"outliers.detail":            "Orden %s (pallet %s, Vehicle Location %d): a %.1f km del centro del pallet",
+
+	// Secuencia de vehicle_location
+	"sequence.duplicated":            "%s: vehicle_location repetidos: %s.",
+	"sequence.missing":               "%s: vehicle_location faltantes: %s.",
+	"sequence.zero":                  "%s: %d órdenes con vehicle_location en cero.",
+	"sequence.multiple_pallets":      "%s: órdenes en más de un pallet: %s.",
+	"sequence.report":                "Se guardaron %d problemas de vehicle_location en %s.",
+	"sequence.all_pallets":           "Todos los pallets",
+	"sequence.kind.duplicated":       "Repetido",
+	"sequence.kind.missing":          "Faltante",
+	"sequence.kind.zero":             "En cero",
+	"sequence.kind.multiple_pallets": "En varios pallets",
+
+	// Estadísticas de ruta
+	"stats.route":            "Ruta %s: %d paradas, %.1f km (tramo más largo %.1f km, promedio %.1f km)",
+	"stats.area":             "Centroide (%.5f, %.5f), envolvente de %.2f km², %.1f paradas/km²",
+	"stats.bbox":             "Límites: (%.5f, %.5f) a (%.5f, %.5f)",
+	"stats.file_created":     "Se guardaron las estadísticas de ruta en %s.",
+	"stats.write_error":      "No se pudieron guardar las estadísticas de ruta: %v",
+	"stats.col.stops":        "Paradas",
+	"stats.col.length":       "Largo (km)",
+	"stats.col.longest_leg":  "Tramo más largo (km)",
+	"stats.col.avg_leg":      "Tramo promedio (km)",
+	"stats.col.min_lat":      "Latitud mínima",
+	"stats.col.min_lon":      "Longitud mínima",
+	"stats.col.max_lat":      "Latitud máxima",
+	"stats.col.max_lon":      "Longitud máxima",
+	"stats.col.centroid_lat": "Latitud del centroide",
+	"stats.col.centroid_lon": "Longitud del centroide",
+	"stats.col.hull_area":    "Área envolvente (km²)",
+	"stats.col.density":      "Paradas por km²",
+	"index.col.orders":       "Órdenes",
+	"index.col.missing":      "Sin geolocalización",
+	"index.col.files":        "Archivos",
+
+	// Comparar extracciones
+	"diff.title":                  "Comparar Coordenadas",
+	"diff.live":                   "API (en vivo)",
+	"diff.summary":                "Antes: %s (%d órdenes). Después: %s (%d órdenes).",
+	"diff.no_changes":             "No hay diferencias entre las extracciones.",
+	"diff.no_pallets":             "%s no indica los pallets para consultar la API; indique un segundo archivo",
+	"diff.added":                  "Órdenes agregadas (%d):",
+	"diff.removed":                "Órdenes eliminadas (%d):",
+	"diff.moved":                  "Coordenadas movidas (%d):",
+	"diff.resequenced":            "Vehicle location cambiados (%d):",
+	"diff.order":                  "%s (pallet %s, Vehicle Location %d)",
+	"diff.moved_order":            "%s (pallet %s): se movió %.0f m",
+	"diff.geolocated_order":       "%s (pallet %s): se geolocalizó",
+	"diff.lost_geolocation_order": "%s (pallet %s): perdió la geolocalización",
+	"diff.resequenced_order":      "%s (pallet %s): %d -> %d",
+	"diff.not_found":              "El archivo %s no existe.",
+	"diff.err_read":               "Error al leer %s",
+	"diff.err_format":             "%s no es una extracción válida: %v",
+	"diff.unknown_format":         "se espera el resumen de coords --json o una exportación GeoJSON (los archivos .txt no identifican todas las órdenes)",
+	"diff.no_order_id":            "incluye paradas sin ID de orden",
+	"diff.html.title":             "Diferencias entre extracciones",
+	"diff.html.order":             "Orden",
+	"diff.html.distance":          "Distancia movida",
+	"diff.html.unchanged":         "Sin cambios",
+	"diff.html.added":             "Agregada",
+	"diff.html.removed":           "Eliminada",
+	"diff.html.moved":             "Movida",
+	"diff.html.resequenced":       "Vehicle location cambiado",
+	"diff.html.geolocated":        "Geolocalizada",
+	"diff.html.lost_geolocation":  "Perdió la geolocalización",
+
+	// Comparar secuencias
+	"compare.title":             "Comparar Secuencias",
+	"compare.summary":           "Plan: %s (%d paradas, %.1f km). Alternativa: %s (%d paradas, %.1f km).",
+	"compare.difference":        "Diferencia de la alternativa: %+.1f km (%+.1f%%).",
+	"compare.by_coordinates":    "Las paradas se emparejaron por coordenadas porque no todas indican su orden.",
+	"compare.no_changes":        "Las dos secuencias visitan las mismas paradas en el mismo orden.",
+	"compare.changed":           "Paradas que cambian de posición (%d):",
+	"compare.stop":              "%s: %d -> %d",
+	"compare.only_planned":      "Solo en el plan (%d):",
+	"compare.only_alternative":  "Solo en la alternativa (%d):",
+	"compare.html.title":        "Plan vs. secuencia alternativa",
+	"compare.html.planned":      "Plan",
+	"compare.html.alternative":  "Alternativa",
+	"compare.html.difference":   "Diferencia",
+	"compare.html.only_changed": "Mostrar solo las paradas con cambios",
+	"compare.html.stop":         "Parada",
+	"compare.html.shift":        "Cambio de posición",
+
+	// Vigilar pallets
+	"watch.title":              "Vigilar Pallets",
+	"watch.start":              "Vigilando %s cada %s. Presione Ctrl+C para terminar.",
+	"watch.initial":            "Extracción inicial: %d órdenes.",
+	"watch.no_changes":         "Sin cambios (%d órdenes).",
+	"watch.changes":            "Cambios: %d agregadas, %d eliminadas, %d movidas, %d con vehicle_location cambiado.",
+	"watch.rewritten":          "Archivos actualizados: %s.*",
+	"watch.empty":              "Los pallets no tienen órdenes; no se reescribieron los archivos.",
+	"watch.rewrite_error":      "No se pudieron reescribir los archivos: %v",
+	"watch.retrying":           "Sin cambios (%d órdenes); se reintentan los archivos que no se pudieron escribir.",
+	"watch.cycle_error":        "Error al consultar la API: %v",
+	"watch.stopped":            "Vigilancia terminada.",
+	"watch.interval_too_short": "el intervalo debe ser de al menos %s",
+
+	// Generar mapa HTML
+	"map.title":               "Generar Mapa HTML",
+	"map.intro":               "Esta herramienta genera un mapa HTML interactivo a partir de un archivo de coordenadas, CSV, GeoJSON, KML o GPX.",
+	"map.prompt":              "Ingrese la ruta del archivo de coordenadas (ej. coordenadas_pl202505danl001.txt): ",
+	"map.no_file":             "Debe proporcionar un archivo de coordenadas.",
+	"map.not_found":           "El archivo especificado no existe: %s",
+	"map.no_coords":           "No se pudieron extraer coordenadas válidas del archivo.",
+	"map.success":             "Se ha generado el mapa con %d puntos.",
+	"map.file_created":        "Archivo HTML creado: %s",
+	"map.open_hint":           "Puedes abrir este archivo en cualquier navegador para ver el mapa interactivo.",
+	"map.err_read":            "error al leer el archivo",
+	"map.err_parse":           "No se pudo interpretar el archivo %s:\n%v",
+	"map.err_template":        "error al procesar la plantilla",
+	"map.err_create":          "error al crear el archivo HTML",
+	"map.err_render":          "error al generar el HTML",
+	"map.offline_missing":     "Este binario no incluye los archivos de Leaflet para mapas sin conexión.\nDescárgalos con \"go generate ./internal/mapassets\" y vuelve a compilar, o genera el mapa sin --offline.",
+	"map.html.title":          "Mapa de Coordenadas",
+	"map.html.toggle":         "Mostrar/Ocultar Línea",
+	"map.html.fit":            "Ajustar Vista",
+	"map.html.orders":         "Órdenes",
+	"map.html.point":          "Punto",
+	"map.html.order":          "Orden",
+	"map.html.tracking":       "Seguimiento",
+	"map.html.customer":       "Cliente",
+	"map.html.address":        "Dirección",
+	"map.html.search":         "Buscar orden, seguimiento, cliente o dirección",
+	"map.html.stops":          "paradas",
+	"map.html.order_count":    "órdenes",
+	"map.html.legend":         "Pallets",
+	"map.html.route":          "Ruta",
+	"map.html.solo":           "Solo",
+	"map.html.show_all":       "Mostrar todos",
+	"map.html.leaflet_failed": "No se pudo cargar la biblioteca del mapa (Leaflet). Revisa la conexión a internet o genera el mapa con --offline.",
+	"map.html.tiles_failed":   "No se pudo cargar el mapa de fondo. Los puntos y las rutas se muestran igual.",
+
+	// Lectura de archivos de coordenadas
+	"parse.position":         "línea %d, columna %d: %s",
+	"parse.number":           "número",
+	"parse.end":              "fin del archivo",
+	"parse.expected":         "se esperaba %s y se encontró %s",
+	"parse.unexpected":       "%s inesperado",
+	"parse.unexpected_text":  "texto inesperado %q",
+	"parse.invalid_number":   "número inválido %q",
+	"parse.unclosed_comment": "comentario sin cerrar",
+	"parse.trailing":         "contenido después de \"]\": %s",
+	"parse.more_errors":      "y %d errores más",
+
+	// Importar paradas para el mapa
+	"import.unsupported_format":   "formato de entrada no soportado: %v",
+	"import.invalid_columns":      "asignación de columnas inválida: %v",
+	"import.csv_empty":            "el archivo CSV está vacío",
+	"import.csv_row":              "fila %d: %s",
+	"import.invalid_coordinates":  "coordenadas inválidas (%q, %q)",
+	"import.csv_column_not_found": "no se encontró la columna %q (columnas: %s)",
+	"import.csv_missing_column":   "no se encontró la columna de %s; indíquela con --columns (columnas: %s)",
+	"import.geojson_type":         "tipo GeoJSON no soportado %q, se espera FeatureCollection o Feature",
+	"import.geojson_point":        "feature %d: el punto debe tener longitud y latitud",
+	"import.xml_point":            "línea %d: coordenadas inválidas %q",
+
+	// Ruta optimizada, corrección X&Y y ayuda
+	"route.title":       "Ruta Optimizada de Pallet",
+	"route.placeholder": "Aquí iría la implementación para mostrar la ruta optimizada.",
+	"route.analyzing":   "Analizando rutas posibles...",
+	"route.distances":   "Calculando distancias...",
+	"route.done":        "Optimización completada. La mejor ruta es: A → C → B → D",
+
+	"xy.title":       "Corrección de X&Y",
+	"xy.placeholder": "Aquí iría la implementación de la corrección de coordenadas.",
+	"xy.processing":  "Procesando coordenadas...",
+	"xy.connecting":  "Conectando a Google Places API...",
+	"xy.done":        "Coordenadas corregidas correctamente.",
+
+	"help.title":          "Ayuda",
+	"help.intro":          "Esta aplicación CLI permite realizar diversas tareas relacionadas con la gestión de coordenadas y rutas.",
+	"help.options":        "Opciones disponibles:",
+	"help.option.xy":      "- Corregir X&Y: Herramienta para ajustar coordenadas usando Google Places",
+	"help.option.route":   "- Mostrar ruta optimizada: Calcula la mejor ruta para un pallet",
+	"help.option.coords":  "- Obtener coordenadas: Extrae coordenadas de un pallet y las guarda en un archivo",
+	"help.option.map":     "- Generar mapa HTML: Crea un mapa interactivo a partir de un archivo de coordenadas",
+	"help.option.help":    "- Ayuda: Muestra esta información",
+	"help.usage":          "Instrucciones de uso:",
+	"help.usage.navigate": "1. Usa las flechas ↑/↓ para navegar por el menú",
+	"help.usage.select":   "2. Presiona Enter para seleccionar una opción",
+	"help.usage.quit":     "3. En cualquier momento puedes presionar q para salir",
+
+	// Subcomandos
+	"cli.unknown_command":    "comando desconocido: %s (use '%s help' para ver los comandos disponibles)",
+	"cli.usage":              "uso: %s %s",
+	"cli.help.usage":         "Uso: %s [opciones] [comando] [argumentos]",
+	"cli.help.menu":          "Sin argumentos se abre el menú interactivo.",
+	"cli.help.commands":      "Comandos:",
+	"cli.help.options":       "Opciones:",
+	"cli.usage.coords":       "coords [--export geojson,csv,xlsx,kml,gpx] [--route] [--columns <columnas>] [--json] [--region <región>] [--outliers mad|iqr|dbscan|off] [--consolidate <metros>] [--map] [--offline] [--output-dir <directorio>] [--name <plantilla>] [--daily] [--overwrite prompt|suffix|overwrite|fail] [--profile <perfil>] <pallet>[,<pallet>...]",
+	"cli.usage.coords_diff":  "coords diff [--map <archivo.html>] [--offline] [--json] <antes> [<después>]",
+	"cli.usage.coords_watch": "coords watch --pallet <pallet> [--interval 2m] [--export <formatos>] [...]",
+	"cli.usage.map":          "map [--format auto|text|csv|geojson|kml|gpx] [--columns lat=<columna>,lon=<columna>,...] [--offline] <archivo>",
+	"cli.usage.map_compare":  "map compare [--format <formato>] [--columns <columnas>] [--map <archivo.html>] [--offline] [--json] <plan> <alternativa>",
+	"cli.usage.completion":   "completion bash|zsh|fish",
+	"cli.usage.help":         "help",
+	"cli.cmd.coords":         "Extrae coordenadas de uno o más pallets y las guarda en un archivo",
+	"cli.cmd.coords_diff":    "Compara dos extracciones (resumen --json o GeoJSON), o una extracción guardada con la API si se omite <después>",
+	"cli.cmd.coords_watch":   "Consulta los pallets cada cierto intervalo y reescribe las exportaciones y el mapa cuando cambian (Ctrl+C para terminar)",
+	"cli.cmd.map":            "Genera un mapa HTML a partir de un archivo de coordenadas, CSV, GeoJSON, KML o GPX",
+	"cli.cmd.map_compare":    "Superpone en un mapa la secuencia planificada y una alternativa (optimizada o editada), con la distancia de cada una y los cambios de posición",
+	"cli.cmd.completion":     "Genera el script de autocompletado para la shell indicada",
+	"cli.cmd.help":           "Muestra la lista de comandos disponibles",
+	"cli.opt.version":        "Muestra la versión",
+	"cli.opt.no_color":       "Desactiva los colores (también con NO_COLOR)",
+	"cli.opt.plain":          "Salida sin colores, sin limpiar la pantalla y sin pausas",
+	"cli.opt.log_level":      "Nivel de detalle del log (por defecto info)",
+	"cli.opt.log_format":     "Formato del log, que se guarda en %s",
+	"cli.unsupported_shell":  "shell no soportada: %s (opciones: bash, zsh, fish)",
+	"cli.completion.command": "comando",
+}
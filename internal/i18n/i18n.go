@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const defaultLang = "es"
+
+var catalogs = map[string]map[string]string{
+	"es": es,
+	"en": en,
+}
+
+var current = defaultLang
+
+// Init selecciona el idioma de los mensajes. Tiene prioridad el valor
+// configurado (ALAS_LANG); si está vacío se usan LC_ALL, LC_MESSAGES y LANG.
+// Si ninguno corresponde a un catálogo disponible se usa español.
+func Init(setting string) {
+	for _, value := range []string{setting, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if lang := normalize(value); lang != "" {
+			current = lang
+			return
+		}
+	}
+	current = defaultLang
+}
+
+// normalize convierte valores como "en_US.UTF-8" en "en".
+func normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "_-.@"); i >= 0 {
+		value = value[:i]
+	}
+	if _, ok := catalogs[value]; ok {
+		return value
+	}
+	return ""
+}
+
+func Lang() string {
+	return current
+}
+
+// T devuelve el mensaje traducido para la clave. Si la clave no existe en el
+// idioma actual se usa el catálogo en español y, en último caso, la clave.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		msg, ok = catalogs[defaultLang][key]
+		if !ok {
+			msg = key
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,229 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// Campos que se pueden leer de un CSV.
+const (
+	FieldLat             = "lat"
+	FieldLon             = "lon"
+	FieldPallet          = "pallet"
+	FieldOrder           = "order"
+	FieldSequence        = "sequence"
+	FieldVehicleLocation = "vehicle_location"
+	FieldTracking        = "tracking"
+	FieldAddress         = "address"
+	FieldCustomer        = "customer"
+	FieldFlags           = "flags"
+)
+
+// Fields son los campos que acepta ParseColumns.
+var Fields = []string{FieldLat, FieldLon, FieldPallet, FieldOrder, FieldSequence, FieldVehicleLocation, FieldTracking, FieldAddress, FieldCustomer, FieldFlags}
+
+// aliases son los encabezados que se reconocen para cada campo cuando no se
+// indica la columna, incluidos los de las exportaciones CSV en español e
+// inglés. Se comparan en minúsculas.
+var aliases = map[string][]string{
+	FieldLat:             {"lat", "latitud", "latitude", "y"},
+	FieldLon:             {"lon", "lng", "long", "longitud", "longitude", "x"},
+	FieldPallet:          {"pallet", "pallet_code", "ruta", "route"},
+	FieldOrder:           {"order_id", "id orden", "order id", "orden", "order", "id", "pedido"},
+	FieldSequence:        {"sequence", "secuencia", "seq", "parada", "stop"},
+	FieldVehicleLocation: {"vehicle_location", "vehicle location", "vl"},
+	FieldTracking:        {"tracking_code", "tracking code", "tracking", "código de seguimiento", "codigo de seguimiento", "seguimiento"},
+	FieldAddress:         {"address", "dirección", "direccion"},
+	FieldCustomer:        {"customer_name", "customer", "cliente", "contact_name", "contacto"},
+	FieldFlags:           {"flags", "observaciones"},
+}
+
+// ParseColumns interpreta una lista "lat=Latitud,lon=Longitud" con el
+// encabezado de la columna de cada campo.
+func ParseColumns(value string) (map[string]string, error) {
+	columnas := map[string]string{}
+	for _, par := range strings.Split(value, ",") {
+		if strings.TrimSpace(par) == "" {
+			continue
+		}
+		campo, encabezado, ok := strings.Cut(par, "=")
+		campo = strings.ToLower(strings.TrimSpace(campo))
+		encabezado = strings.TrimSpace(encabezado)
+		if !ok || encabezado == "" || !isField(campo) {
+			return nil, fmt.Errorf("%s (%s)", strings.TrimSpace(par), strings.Join(Fields, ", "))
+		}
+		columnas[campo] = encabezado
+	}
+	return columnas, nil
+}
+
+func isField(campo string) bool {
+	for _, f := range Fields {
+		if f == campo {
+			return true
+		}
+	}
+	return false
+}
+
+// readCSV lee una parada por fila. El separador (coma, punto y coma o
+// tabulación) se detecta en el encabezado; con punto y coma o tabulación se
+// acepta la coma decimal.
+func readCSV(contenido []byte, columnas map[string]string) ([]parada, error) {
+	contenido = bytes.TrimPrefix(contenido, []byte("\ufeff"))
+	primera, _, _ := bytes.Cut(contenido, []byte("\n"))
+
+	r := csv.NewReader(bytes.NewReader(contenido))
+	r.Comma = detectDelimiter(string(primera))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	filas, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(filas) == 0 {
+		return nil, errors.New(i18n.T("import.csv_empty"))
+	}
+
+	indices, err := mapColumns(filas[0], columnas)
+	if err != nil {
+		return nil, err
+	}
+
+	var paradas []parada
+	var errs []error
+	for n, fila := range filas[1:] {
+		linea := n + 2
+		if isBlank(fila) {
+			continue
+		}
+
+		valor := func(campo string) string {
+			i, ok := indices[campo]
+			if !ok || i >= len(fila) {
+				return ""
+			}
+			return strings.TrimSpace(fila[i])
+		}
+
+		lat, errLat := parseDecimal(valor(FieldLat), r.Comma)
+		lon, errLon := parseDecimal(valor(FieldLon), r.Comma)
+		if errLat != nil || errLon != nil {
+			errs = append(errs, errors.New(i18n.T("import.csv_row", linea, i18n.T("import.invalid_coordinates", valor(FieldLat), valor(FieldLon)))))
+			continue
+		}
+
+		p := parada{coord: models.Coordenada{
+			Lat:     lat,
+			Lon:     lon,
+			Pallet:  valor(FieldPallet),
+			Flags:   splitList(valor(FieldFlags)),
+			Address: valor(FieldAddress),
+		}}
+		vls := splitList(valor(FieldVehicleLocation))
+		// Los nombres de cliente pueden tener espacios: solo se separan por ";"
+		setOrders(&p.coord, stopOrders{
+			ids:              splitList(valor(FieldOrder)),
+			vehicleLocations: vls,
+			trackingCodes:    splitList(valor(FieldTracking)),
+			customers:        strings.Split(valor(FieldCustomer), ";"),
+		})
+
+		// Sin columna de secuencia se ordena por vehicle_location
+		seq := splitList(valor(FieldSequence))
+		if _, ok := indices[FieldSequence]; !ok {
+			seq = vls
+		}
+		if len(seq) > 0 {
+			if n, err := strconv.Atoi(seq[0]); err == nil {
+				p.secuencia, p.conOrden = n, true
+			}
+		}
+		paradas = append(paradas, p)
+	}
+
+	return paradas, joinErrors(errs)
+}
+
+// mapColumns ubica cada campo en el encabezado: primero las columnas
+// indicadas y luego los nombres conocidos.
+func mapColumns(encabezado []string, columnas map[string]string) (map[string]int, error) {
+	normalizados := make([]string, len(encabezado))
+	for i, h := range encabezado {
+		normalizados[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+	buscar := func(nombre string) (int, bool) {
+		for i, h := range normalizados {
+			if h == strings.ToLower(nombre) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+
+	indices := map[string]int{}
+	for _, campo := range Fields {
+		if nombre, ok := columnas[campo]; ok {
+			i, encontrado := buscar(nombre)
+			if !encontrado {
+				return nil, errors.New(i18n.T("import.csv_column_not_found", nombre, strings.Join(encabezado, ", ")))
+			}
+			indices[campo] = i
+			continue
+		}
+		for _, alias := range aliases[campo] {
+			if i, ok := buscar(alias); ok {
+				indices[campo] = i
+				break
+			}
+		}
+	}
+
+	for _, campo := range []string{FieldLat, FieldLon} {
+		if _, ok := indices[campo]; !ok {
+			return nil, errors.New(i18n.T("import.csv_missing_column", campo, strings.Join(encabezado, ", ")))
+		}
+	}
+	return indices, nil
+}
+
+func detectDelimiter(linea string) rune {
+	mejor, cantidad := ',', strings.Count(linea, ",")
+	for _, d := range []rune{';', '\t'} {
+		if n := strings.Count(linea, string(d)); n > cantidad {
+			mejor, cantidad = d, n
+		}
+	}
+	return mejor
+}
+
+func parseDecimal(value string, delimiter rune) (float64, error) {
+	if delimiter != ',' {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func isBlank(fila []string) bool {
+	for _, v := range fila {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func joinErrors(errs []error) error {
+	if len(errs) > maxErrors {
+		errs = append(errs[:maxErrors], errors.New(i18n.T("parse.more_errors", len(errs)-maxErrors)))
+	}
+	return errors.Join(errs...)
+}
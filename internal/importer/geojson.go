@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+type geoJSONFeature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// readGeoJSON lee los Point y MultiPoint de un FeatureCollection, o de un
+// Feature suelto. Las propiedades de las exportaciones (pallet, order_id,
+// order_ids, tracking_code, customer_name, address, sequence,
+// vehicle_location y flags) se conservan.
+func readGeoJSON(contenido []byte) ([]parada, error) {
+	var documento struct {
+		Type     string           `json:"type"`
+		Features []geoJSONFeature `json:"features"`
+	}
+	if err := json.Unmarshal(contenido, &documento); err != nil {
+		return nil, err
+	}
+
+	features := documento.Features
+	switch documento.Type {
+	case "FeatureCollection":
+	case "Feature":
+		var feature geoJSONFeature
+		if err := json.Unmarshal(contenido, &feature); err != nil {
+			return nil, err
+		}
+		features = []geoJSONFeature{feature}
+	default:
+		return nil, errors.New(i18n.T("import.geojson_type", documento.Type))
+	}
+
+	var paradas []parada
+	var errs []error
+	for n, f := range features {
+		if f.Geometry == nil {
+			continue
+		}
+
+		var puntos [][]float64
+		switch f.Geometry.Type {
+		case "Point":
+			var punto []float64
+			if err := json.Unmarshal(f.Geometry.Coordinates, &punto); err != nil {
+				errs = append(errs, fmt.Errorf("feature %d: %w", n+1, err))
+				continue
+			}
+			puntos = [][]float64{punto}
+		case "MultiPoint":
+			if err := json.Unmarshal(f.Geometry.Coordinates, &puntos); err != nil {
+				errs = append(errs, fmt.Errorf("feature %d: %w", n+1, err))
+				continue
+			}
+		default:
+			// Las líneas de ruta de las exportaciones no son paradas
+			continue
+		}
+
+		for _, punto := range puntos {
+			if len(punto) < 2 {
+				errs = append(errs, errors.New(i18n.T("import.geojson_point", n+1)))
+				continue
+			}
+			// GeoJSON usa el orden [longitud, latitud]
+			p := parada{coord: models.Coordenada{
+				Lat:          punto[1],
+				Lon:          punto[0],
+				Pallet:       stringProperty(f.Properties, "pallet"),
+				Flags:        listProperty(f.Properties, "flags"),
+				TrackingCode: stringProperty(f.Properties, "tracking_code"),
+				CustomerName: stringProperty(f.Properties, "customer_name"),
+				Address:      stringProperty(f.Properties, "address"),
+			}}
+			setOrders(&p.coord, stopOrders{
+				ids:              listPropertyOr(f.Properties, "order_ids", "order_id"),
+				vehicleLocations: listPropertyOr(f.Properties, "vehicle_locations", "vehicle_location"),
+				trackingCodes:    listProperty(f.Properties, "tracking_codes"),
+				customers:        stringList(f.Properties["customer_names"]),
+			})
+			for _, clave := range []string{"sequence", "vehicle_location"} {
+				if seq, ok := f.Properties[clave].(float64); ok {
+					p.secuencia, p.conOrden = int(seq), true
+					break
+				}
+			}
+			paradas = append(paradas, p)
+		}
+	}
+
+	return paradas, joinErrors(errs)
+}
+
+func stringProperty(properties map[string]any, clave string) string {
+	if valor, ok := properties[clave].(string); ok {
+		return valor
+	}
+	return ""
+}
+
+// listPropertyOr devuelve la lista de la propiedad de una parada consolidada
+// o, si no está, la de la orden única.
+func listPropertyOr(properties map[string]any, lista, unica string) []string {
+	if valores := listProperty(properties, lista); len(valores) > 0 {
+		return valores
+	}
+	return listProperty(properties, unica)
+}
+
+// stringList convierte una lista JSON de textos sin separarlos.
+func stringList(valor any) []string {
+	lista, _ := valor.([]any)
+	var textos []string
+	for _, v := range lista {
+		textos = append(textos, fmt.Sprint(v))
+	}
+	return textos
+}
+
+// listProperty acepta tanto una lista JSON como un texto separado por comas
+// o punto y coma.
+func listProperty(properties map[string]any, clave string) []string {
+	switch valor := properties[clave].(type) {
+	case []any:
+		var lista []string
+		for _, v := range valor {
+			lista = append(lista, fmt.Sprint(v))
+		}
+		return lista
+	case string:
+		return splitList(valor)
+	case float64:
+		return []string{strconv.FormatFloat(valor, 'f', -1, 64)}
+	}
+	return nil
+}
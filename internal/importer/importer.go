@@ -0,0 +1,238 @@
+// Package importer lee listas de paradas en los formatos que aceptan los
+// mapas: el texto de coordenadas de la extracción, CSV, GeoJSON, KML y GPX.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+const (
+	FormatText    = "text"
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+	FormatKML     = "kml"
+	FormatGPX     = "gpx"
+)
+
+// FormatAuto detecta el formato por la extensión o el contenido.
+const FormatAuto = "auto"
+
+// Formats son los formatos de entrada soportados.
+var Formats = []string{FormatText, FormatCSV, FormatGeoJSON, FormatKML, FormatGPX}
+
+// Cantidad máxima de errores que se informan de un mismo archivo.
+const maxErrors = 10
+
+var extensions = map[string]string{
+	".txt":     FormatText,
+	".csv":     FormatCSV,
+	".tsv":     FormatCSV,
+	".geojson": FormatGeoJSON,
+	".json":    FormatGeoJSON,
+	".kml":     FormatKML,
+	".gpx":     FormatGPX,
+}
+
+// Extensions devuelve las extensiones de archivo que se reconocen, ordenadas.
+func Extensions() []string {
+	var lista []string
+	for ext := range extensions {
+		lista = append(lista, ext)
+	}
+	sort.Strings(lista)
+	return lista
+}
+
+// Options controla cómo se lee el archivo.
+type Options struct {
+	// Format es uno de Formats, o vacío para detectarlo.
+	Format string
+	// Columns asigna a cada campo de Fields el encabezado de la columna CSV
+	// que lo contiene.
+	Columns map[string]string
+}
+
+// ParseFormat valida el formato de entrada; vacío o auto lo detecta.
+func ParseFormat(value string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(value))
+	if format == "" || format == FormatAuto {
+		return "", nil
+	}
+	for _, f := range Formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%s (%s, %s)", value, FormatAuto, strings.Join(Formats, ", "))
+}
+
+// Detect elige el formato por la extensión del archivo y, si no la reconoce,
+// por su contenido.
+func Detect(path string, contenido []byte) string {
+	if format, ok := extensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format
+	}
+
+	inicio := bytes.TrimSpace(bytes.TrimPrefix(contenido, []byte("\ufeff")))
+	cabecera := strings.ToLower(string(inicio[:min(len(inicio), 512)]))
+	switch {
+	case strings.HasPrefix(cabecera, "{"):
+		return FormatGeoJSON
+	case strings.HasPrefix(cabecera, "<"):
+		if strings.Contains(cabecera, "<gpx") {
+			return FormatGPX
+		}
+		return FormatKML
+	case strings.HasPrefix(cabecera, "[") || strings.HasPrefix(cabecera, "(") || strings.HasPrefix(cabecera, "/*"):
+		return FormatText
+	}
+
+	// Una primera línea con letras y separadores es el encabezado de un CSV
+	primera, _, _ := strings.Cut(cabecera, "\n")
+	if strings.ContainsAny(primera, ",;\t") && strings.IndexFunc(primera, isLetter) >= 0 {
+		return FormatCSV
+	}
+	return FormatText
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || r > 127
+}
+
+// Read lee las paradas del archivo y devuelve el formato usado. Las paradas
+// quedan agrupadas por pallet y numeradas por separado en cada uno.
+func Read(path string, opts Options) ([]models.Coordenada, string, error) {
+	contenido, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = Detect(path, contenido)
+	}
+
+	var paradas []parada
+	switch format {
+	case FormatText:
+		paradas, err = readText(contenido)
+	case FormatCSV:
+		paradas, err = readCSV(contenido, opts.Columns)
+	case FormatGeoJSON:
+		paradas, err = readGeoJSON(contenido)
+	case FormatKML:
+		paradas, err = readKML(contenido)
+	case FormatGPX:
+		paradas, err = readGPX(contenido)
+	default:
+		_, err = ParseFormat(format)
+	}
+	if err != nil {
+		return nil, format, err
+	}
+
+	return numerar(paradas), format, nil
+}
+
+// parada es una coordenada leída con su secuencia en el archivo, si la
+// indica.
+type parada struct {
+	coord     models.Coordenada
+	secuencia int
+	conOrden  bool
+}
+
+// numerar agrupa las paradas por pallet en el orden en que aparece cada uno.
+// Si todas indican su secuencia, se ordenan por ella dentro del pallet.
+func numerar(paradas []parada) []models.Coordenada {
+	pallets := map[string]int{}
+	conOrden := true
+	for _, p := range paradas {
+		if _, ok := pallets[p.coord.Pallet]; !ok {
+			pallets[p.coord.Pallet] = len(pallets)
+		}
+		conOrden = conOrden && p.conOrden
+	}
+
+	sort.SliceStable(paradas, func(i, j int) bool {
+		pi, pj := pallets[paradas[i].coord.Pallet], pallets[paradas[j].coord.Pallet]
+		if pi != pj {
+			return pi < pj
+		}
+		return conOrden && paradas[i].secuencia < paradas[j].secuencia
+	})
+
+	var coordenadas []models.Coordenada
+	numero := 0
+	for i, p := range paradas {
+		if i == 0 || p.coord.Pallet != paradas[i-1].coord.Pallet {
+			numero = 0
+		}
+		numero++
+		p.coord.Index = numero
+		coordenadas = append(coordenadas, p.coord)
+	}
+	return coordenadas
+}
+
+// stopOrders son los datos de cada orden de una parada, en el mismo orden.
+type stopOrders struct {
+	ids, vehicleLocations, trackingCodes, customers []string
+}
+
+// setOrders completa la parada con los datos de sus órdenes. La parada toma
+// los de la primera y, si hay más de una, se listan todas en Orders, como en
+// las paradas consolidadas.
+func setOrders(c *models.Coordenada, o stopOrders) {
+	valor := func(lista []string, i int) string {
+		if i >= len(lista) {
+			return ""
+		}
+		return strings.TrimSpace(lista[i])
+	}
+	vl := func(i int) int {
+		n, _ := strconv.Atoi(valor(o.vehicleLocations, i))
+		return n
+	}
+
+	c.OrderID = valor(o.ids, 0)
+	c.VehicleLocation = vl(0)
+	if codigo := valor(o.trackingCodes, 0); codigo != "" {
+		c.TrackingCode = codigo
+	}
+	if cliente := valor(o.customers, 0); cliente != "" {
+		c.CustomerName = cliente
+	}
+	if len(o.ids) < 2 {
+		return
+	}
+	for i, id := range o.ids {
+		orden := models.StopOrder{
+			OrderID:         id,
+			TrackingCode:    valor(o.trackingCodes, i),
+			CustomerName:    valor(o.customers, i),
+			VehicleLocation: vl(i),
+			Lat:             c.Lat,
+			Lon:             c.Lon,
+		}
+		if i == 0 {
+			orden.Address = c.Address
+		}
+		c.Orders = append(c.Orders, orden)
+	}
+}
+
+// splitList separa listas como "101;102" o "101, 102".
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' '
+	})
+}
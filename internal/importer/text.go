@@ -0,0 +1,53 @@
+package importer
+
+import (
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/coords"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// readText lee el archivo de coordenadas de la extracción, limpio o con sus
+// comentarios, de los que recupera el pallet, el vehicle_location, las órdenes
+// consolidadas y los motivos de validación.
+func readText(contenido []byte) ([]parada, error) {
+	puntos, err := coords.ParseCoordinates(string(contenido))
+	if err != nil {
+		return nil, err
+	}
+
+	var paradas []parada
+	for _, punto := range puntos {
+		coord := models.Coordenada{
+			Lat:    punto.Lat,
+			Lon:    punto.Lon,
+			Pallet: commentField(punto.Comment, "Pallet"),
+			Flags:  strings.Fields(commentField(punto.Comment, "Flags")),
+		}
+		setOrders(&coord, stopOrders{
+			ids:              strings.Fields(commentField(punto.Comment, "Órdenes")),
+			vehicleLocations: commentVehicleLocations(punto.Comment),
+		})
+		paradas = append(paradas, parada{coord: coord})
+	}
+	return paradas, nil
+}
+
+// commentVehicleLocations devuelve los vehicle_location del comentario. Las
+// paradas consolidadas listan el de cada orden seguido de la cantidad, como
+// "Vehicle Location: 3 7 (2 órdenes)".
+func commentVehicleLocations(comentario string) []string {
+	valor, _, _ := strings.Cut(commentField(comentario, "Vehicle Location"), "(")
+	return strings.Fields(valor)
+}
+
+// commentField devuelve el valor de "clave: valor" en un comentario de la
+// forma "Orden #1, Vehicle Location: 3, Pallet: pl...".
+func commentField(comentario, clave string) string {
+	for _, parte := range strings.Split(comentario, ", ") {
+		if valor, ok := strings.CutPrefix(parte, clave+": "); ok {
+			return strings.TrimSpace(valor)
+		}
+	}
+	return ""
+}
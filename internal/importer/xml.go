@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/models"
+)
+
+// readKML lee los Point de los Placemark. El nombre de la carpeta que los
+// contiene se usa como pallet, como en las exportaciones KML.
+func readKML(contenido []byte) ([]parada, error) {
+	d := xml.NewDecoder(bytes.NewReader(contenido))
+
+	var pila, carpetas []string
+	var texto strings.Builder
+	var paradas []parada
+	var errs []error
+
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			pila = append(pila, t.Name.Local)
+			if t.Name.Local == "Folder" {
+				carpetas = append(carpetas, "")
+			}
+			texto.Reset()
+		case xml.CharData:
+			texto.Write(t)
+		case xml.EndElement:
+			padre := ""
+			if len(pila) >= 2 {
+				padre = pila[len(pila)-2]
+			}
+			switch {
+			case t.Name.Local == "name" && padre == "Folder":
+				carpetas[len(carpetas)-1] = strings.TrimSpace(texto.String())
+			case t.Name.Local == "coordinates" && padre == "Point":
+				linea, _ := d.InputPos()
+				lat, lon, err := parseKMLCoordinates(texto.String())
+				if err != nil {
+					errs = append(errs, errors.New(i18n.T("import.xml_point", linea, strings.TrimSpace(texto.String()))))
+					break
+				}
+				paradas = append(paradas, parada{coord: models.Coordenada{Lat: lat, Lon: lon, Pallet: currentFolder(carpetas)}})
+			case t.Name.Local == "Folder":
+				carpetas = carpetas[:len(carpetas)-1]
+			}
+			pila = pila[:len(pila)-1]
+		}
+	}
+
+	return paradas, joinErrors(errs)
+}
+
+func currentFolder(carpetas []string) string {
+	for i := len(carpetas) - 1; i >= 0; i-- {
+		if carpetas[i] != "" {
+			return carpetas[i]
+		}
+	}
+	return ""
+}
+
+// parseKMLCoordinates interpreta "lon,lat[,alt]".
+func parseKMLCoordinates(value string) (float64, float64, error) {
+	partes := strings.Split(strings.TrimSpace(value), ",")
+	if len(partes) < 2 {
+		return 0, 0, strconv.ErrSyntax
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(partes[0]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(partes[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Type string  `xml:"type"`
+}
+
+// readGPX lee los waypoints, usando su tipo como pallet. Si el archivo no
+// tiene waypoints usa los puntos de las rutas o, en su defecto, de los
+// tracks, con el nombre de cada ruta o track como pallet.
+func readGPX(contenido []byte) ([]parada, error) {
+	var documento struct {
+		Waypoints []gpxPoint `xml:"wpt"`
+		Routes    []struct {
+			Name   string     `xml:"name"`
+			Points []gpxPoint `xml:"rtept"`
+		} `xml:"rte"`
+		Tracks []struct {
+			Name     string `xml:"name"`
+			Segments []struct {
+				Points []gpxPoint `xml:"trkpt"`
+			} `xml:"trkseg"`
+		} `xml:"trk"`
+	}
+	if err := xml.Unmarshal(contenido, &documento); err != nil {
+		return nil, err
+	}
+
+	var paradas []parada
+	agregar := func(p gpxPoint, pallet string) {
+		paradas = append(paradas, parada{coord: models.Coordenada{Lat: p.Lat, Lon: p.Lon, Pallet: pallet}})
+	}
+
+	for _, w := range documento.Waypoints {
+		agregar(w, w.Type)
+	}
+	if len(paradas) == 0 {
+		for _, r := range documento.Routes {
+			for _, p := range r.Points {
+				agregar(p, r.Name)
+			}
+		}
+	}
+	if len(paradas) == 0 {
+		for _, t := range documento.Tracks {
+			for _, s := range t.Segments {
+				for _, p := range s.Points {
+					agregar(p, t.Name)
+				}
+			}
+		}
+	}
+	return paradas, nil
+}
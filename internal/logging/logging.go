@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/apperr"
+	"github.com/Cait-dev/alas-tools-cli/internal/config"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+const (
+	logFileName   = "alas-tools-cli.log"
+	maxLogSize    = 5 * 1024 * 1024
+	maxLogBackups = 3
+	DefaultLevel  = "info"
+	DefaultFormat = "text"
+)
+
+var logFile io.Closer
+
+// Setup configura el logger por defecto de slog para que escriba en el
+// archivo de log rotativo del directorio de estado. Los mensajes de log no se
+// muestran en la terminal.
+func Setup(level, format string) error {
+	slogLevel, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	dir, err := config.StateDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	file, err := newRotatingFile(filepath.Join(dir, logFileName), maxLogSize, maxLogBackups)
+	if err != nil {
+		return err
+	}
+
+	handler, err := newHandler(file, slogLevel, format)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	logFile = file
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Discard deja el logger por defecto sin salida, para cuando no se pudo abrir
+// el archivo de log.
+func Discard() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func Close() {
+	if logFile != nil {
+		logFile.Close()
+	}
+}
+
+func Path() string {
+	dir, err := config.StateDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, logFileName)
+}
+
+func parseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, apperr.New(apperr.KindUsage, i18n.T("log.err_level", level))
+}
+
+func newHandler(w io.Writer, level slog.Level, format string) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(format) {
+	case "", "text":
+		return slog.NewTextHandler(w, opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	}
+	return nil, apperr.New(apperr.KindUsage, i18n.T("log.err_format", format))
+}
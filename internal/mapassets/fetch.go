@@ -0,0 +1,69 @@
+//go:build ignore
+
+// fetch descarga los archivos de Leaflet que se incluyen en el binario para
+// los mapas sin conexión. Se ejecuta con go generate ./internal/mapassets.
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/mapassets"
+)
+
+const base = "https://unpkg.com/leaflet@" + mapassets.LeafletVersion + "/"
+
+// archivos son las rutas dentro del paquete de Leaflet; se guardan en el
+// directorio leaflet sin el prefijo dist/.
+var archivos = []string{
+	"LICENSE",
+	"dist/leaflet.js",
+	"dist/leaflet.css",
+	"dist/images/layers.png",
+	"dist/images/layers-2x.png",
+	"dist/images/marker-icon.png",
+	"dist/images/marker-icon-2x.png",
+	"dist/images/marker-shadow.png",
+}
+
+func main() {
+	client := &http.Client{Timeout: 60 * time.Second}
+	for _, archivo := range archivos {
+		destino := filepath.Join("leaflet", filepath.FromSlash(strings.TrimPrefix(archivo, "dist/")))
+		if err := descargar(client, base+archivo, destino); err != nil {
+			fmt.Fprintf(os.Stderr, "error al descargar %s: %v\n", archivo, err)
+			os.Exit(1)
+		}
+		fmt.Println("descargado", destino)
+	}
+}
+
+func descargar(client *http.Client, url, destino string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destino), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(destino)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,70 @@
+// Package mapassets incluye en el binario los archivos de Leaflet para
+// generar mapas HTML que funcionan sin conexión.
+//
+// Los archivos se descargan en el directorio leaflet con
+//
+//	go generate ./internal/mapassets
+//
+// antes de compilar. Un binario compilado sin ellos sigue cargando Leaflet
+// desde el CDN y rechaza la opción --offline.
+package mapassets
+
+//go:generate go run fetch.go
+
+import (
+	"embed"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// LeafletVersion es la versión de Leaflet que descarga fetch.go y que usan
+// los mapas en línea.
+const LeafletVersion = "1.9.4"
+
+// ErrMissing indica que el binario se compiló sin los archivos de Leaflet.
+var ErrMissing = errors.New("leaflet assets not embedded")
+
+//go:embed leaflet
+var files embed.FS
+
+// Available indica si el binario incluye los archivos de Leaflet.
+func Available() bool {
+	_, errJS := fs.Stat(files, "leaflet/leaflet.js")
+	_, errCSS := fs.Stat(files, "leaflet/leaflet.css")
+	return errJS == nil && errCSS == nil
+}
+
+// Leaflet son los archivos listos para incluir en el HTML.
+type Leaflet struct {
+	JS  string
+	CSS string
+	// Icons son las imágenes del marcador por defecto como data URI.
+	Icons map[string]string
+}
+
+// Load lee los archivos incluidos. Las imágenes que referencia el CSS se
+// reemplazan por data URI para que el HTML no dependa de otros archivos.
+func Load() (Leaflet, error) {
+	js, errJS := fs.ReadFile(files, "leaflet/leaflet.js")
+	css, errCSS := fs.ReadFile(files, "leaflet/leaflet.css")
+	if errJS != nil || errCSS != nil {
+		return Leaflet{}, ErrMissing
+	}
+
+	l := Leaflet{JS: string(js), CSS: string(css), Icons: map[string]string{}}
+	imagenes, _ := fs.Glob(files, "leaflet/images/*.png")
+	for _, imagen := range imagenes {
+		contenido, err := fs.ReadFile(files, imagen)
+		if err != nil {
+			return Leaflet{}, err
+		}
+		nombre := path.Base(imagen)
+		uri := "data:image/png;base64," + base64.StdEncoding.EncodeToString(contenido)
+		l.CSS = strings.ReplaceAll(l.CSS, "images/"+nombre, uri)
+		l.Icons[nombre] = uri
+	}
+	return l, nil
+}
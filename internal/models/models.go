@@ -1,16 +1,147 @@
 package models
 
+import (
+	"encoding/json"
+)
+
 type CoordInfo struct {
-	Lat             float64
-	Lon             float64
-	VehicleLocation int
-	Index           int
+	Lat             float64  `json:"lat"`
+	Lon             float64  `json:"lon"`
+	VehicleLocation int      `json:"vehicle_location"`
+	Index           int      `json:"-"`
+	OrderID         string   `json:"order_id"`
+	PalletCode      string   `json:"pallet"`
+	TrackingCode    string   `json:"tracking_code,omitempty"`
+	CustomerName    string   `json:"customer_name,omitempty"`
+	Address         string   `json:"address"`
+	Commune         string   `json:"commune,omitempty"`
+	Flags           []string `json:"flags,omitempty"`
+	// Orders lista todas las órdenes de una parada consolidada, incluida la
+	// propia; queda vacío cuando la parada tiene una sola orden.
+	Orders []StopOrder `json:"orders,omitempty"`
+}
+
+// StopOrder es una de las órdenes agrupadas en una parada consolidada.
+type StopOrder struct {
+	OrderID         string  `json:"order_id"`
+	TrackingCode    string  `json:"tracking_code,omitempty"`
+	CustomerName    string  `json:"customer_name,omitempty"`
+	VehicleLocation int     `json:"vehicle_location"`
+	Address         string  `json:"address"`
+	Lat             float64 `json:"lat"`
+	Lon             float64 `json:"lon"`
+}
+
+// OrderCount devuelve la cantidad de órdenes de la parada.
+func (c CoordInfo) OrderCount() int {
+	if len(c.Orders) == 0 {
+		return 1
+	}
+	return len(c.Orders)
+}
+
+// OrderIDs devuelve los identificadores de las órdenes de la parada.
+func (c CoordInfo) OrderIDs() []string {
+	if len(c.Orders) == 0 {
+		return []string{c.OrderID}
+	}
+	var ids []string
+	for _, o := range c.Orders {
+		ids = append(ids, o.OrderID)
+	}
+	return ids
+}
+
+// VehicleLocations devuelve los vehicle_location de las órdenes de la parada.
+func (c CoordInfo) VehicleLocations() []int {
+	if len(c.Orders) == 0 {
+		return []int{c.VehicleLocation}
+	}
+	var vls []int
+	for _, o := range c.Orders {
+		vls = append(vls, o.VehicleLocation)
+	}
+	return vls
+}
+
+// TrackingCodes devuelve los códigos de seguimiento de las órdenes de la
+// parada.
+func (c CoordInfo) TrackingCodes() []string {
+	if len(c.Orders) == 0 {
+		return []string{c.TrackingCode}
+	}
+	var codigos []string
+	for _, o := range c.Orders {
+		codigos = append(codigos, o.TrackingCode)
+	}
+	return codigos
+}
+
+// CustomerNames devuelve los clientes de las órdenes de la parada.
+func (c CoordInfo) CustomerNames() []string {
+	if len(c.Orders) == 0 {
+		return []string{c.CustomerName}
+	}
+	var clientes []string
+	for _, o := range c.Orders {
+		clientes = append(clientes, o.CustomerName)
+	}
+	return clientes
+}
+
+// MissingOrder es una orden sin geolocalización (latitud o longitud en cero),
+// que no puede ubicarse en el mapa y debe enviarse a corrección de X&Y.
+type MissingOrder struct {
+	OrderID         string `json:"order_id"`
+	PalletCode      string `json:"pallet"`
+	Address         string `json:"address"`
+	Commune         string `json:"commune,omitempty"`
+	VehicleLocation int    `json:"vehicle_location"`
+}
+
+// SequenceIssue es un problema en la secuencia de vehicle_location: un valor
+// repetido, faltante o en cero, o una orden que aparece en más de un pallet.
+// En este último caso Pallet y VehicleLocation quedan vacíos.
+type SequenceIssue struct {
+	Pallet          string   `json:"pallet"`
+	Kind            string   `json:"kind"`
+	VehicleLocation int      `json:"vehicle_location"`
+	OrderIDs        []string `json:"order_ids,omitempty"`
+}
+
+// FlexibleString acepta valores JSON de texto o numéricos, ya que la API no
+// siempre devuelve los identificadores con el mismo tipo.
+type FlexibleString string
+
+func (f *FlexibleString) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = FlexibleString(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*f = FlexibleString(n.String())
+	return nil
+}
+
+func (f FlexibleString) String() string {
+	return string(f)
 }
 
 type DeliveryOrderResponse struct {
 	Total int `json:"total"`
 	Items []struct {
-		Destination struct {
+		ID           FlexibleString `json:"id"`
+		PalletCode   string         `json:"pallet_code"`
+		TrackingCode string         `json:"tracking_code"`
+		Destination  struct {
+			Address     string `json:"address"`
+			Commune     string `json:"commune"`
+			ContactName string `json:"contact_name"`
 			GeoLocation struct {
 				Lat float64 `json:"lat"`
 				Lon float64 `json:"lon"`
@@ -24,10 +155,46 @@ type Coordenada struct {
 	Lat   float64
 	Lon   float64
 	Index int
+	// Pallet separa la secuencia de cada pallet en el mapa; vacío si el
+	// origen es un archivo de texto.
+	Pallet string
+	Flags  []string
+	// Datos de la orden que se muestran en el mapa; quedan vacíos si el
+	// archivo de origen no los incluye.
+	OrderID         string
+	TrackingCode    string
+	CustomerName    string
+	Address         string
+	VehicleLocation int
+	// Orders lista las órdenes de la parada cuando se consolidó más de una.
+	Orders []StopOrder
 }
 
 type MapData struct {
 	Coordenadas []Coordenada
 	CentroLat   float64
 	CentroLon   float64
+	// Pallets resume cada pallet del mapa, en el orden de las coordenadas.
+	Pallets []MapPallet
+}
+
+// MapPallet es una capa del mapa: las paradas de un pallet con su color y el
+// largo de la ruta que las une en orden.
+type MapPallet struct {
+	Code string `json:"code"`
+	// Color queda vacío cuando el mapa tiene un solo pallet.
+	Color    string  `json:"color"`
+	Stops    int     `json:"stops"`
+	Orders   int     `json:"orders"`
+	LengthKm float64 `json:"length_km"`
+}
+
+// PalletFiles resume los archivos generados para un pallet cuando se extraen
+// varios a la vez. Es una fila del índice combinado.
+type PalletFiles struct {
+	Pallet             string   `json:"pallet"`
+	Orders             int      `json:"orders"`
+	Stops              int      `json:"stops"`
+	MissingGeolocation int      `json:"missing_geolocation"`
+	Files              []string `json:"files"`
 }
@@ -0,0 +1,158 @@
+package outfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Políticas para cuando los archivos de una extracción ya existen.
+const (
+	PolicyPrompt    = "prompt"
+	PolicySuffix    = "suffix"
+	PolicyOverwrite = "overwrite"
+	PolicyFail      = "fail"
+)
+
+// Policies son las políticas aceptadas por ParsePolicy.
+var Policies = []string{PolicyPrompt, PolicySuffix, PolicyOverwrite, PolicyFail}
+
+// DefaultTemplate conserva los nombres de archivo de versiones anteriores.
+const DefaultTemplate = "coordenadas_{pallet}"
+
+// DefaultProfile se usa en {profile} cuando no se configuró un perfil.
+const DefaultProfile = "default"
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "150405"
+)
+
+var placeholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Placeholders son las variables que admite una plantilla de nombre.
+var Placeholders = []string{"{pallet}", "{date}", "{time}", "{profile}"}
+
+// Options indica dónde y con qué nombre se escriben los archivos generados.
+type Options struct {
+	// Dir es el directorio de salida; vacío para el directorio actual.
+	Dir string
+	// Template es el nombre de los archivos, sin extensión.
+	Template string
+	// Daily agrupa los archivos en una subcarpeta por día (AAAA-MM-DD).
+	Daily   bool
+	Policy  string
+	Profile string
+}
+
+// ParsePolicy valida la política de sobrescritura; vacía equivale a prompt.
+func ParsePolicy(value string) (string, error) {
+	policy := strings.ToLower(strings.TrimSpace(value))
+	if policy == "" {
+		return PolicyPrompt, nil
+	}
+	for _, p := range Policies {
+		if p == policy {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("%s (%s)", value, strings.Join(Policies, ", "))
+}
+
+// ParseTemplate valida una plantilla de nombre; vacía equivale a
+// DefaultTemplate.
+func ParseTemplate(value string) (string, error) {
+	tmpl := strings.TrimSpace(value)
+	if tmpl == "" {
+		return DefaultTemplate, nil
+	}
+	for _, ph := range placeholder.FindAllString(tmpl, -1) {
+		if !isPlaceholder(ph) {
+			return "", fmt.Errorf("%s (%s)", ph, strings.Join(Placeholders, ", "))
+		}
+	}
+	if strings.ContainsAny(placeholder.ReplaceAllString(tmpl, ""), "{}") {
+		return "", fmt.Errorf("%s", tmpl)
+	}
+	return tmpl, nil
+}
+
+func isPlaceholder(value string) bool {
+	for _, ph := range Placeholders {
+		if ph == value {
+			return true
+		}
+	}
+	return false
+}
+
+// BaseName devuelve la ruta de los archivos de los pallets, sin extensión,
+// aplicando la plantilla, el directorio y la subcarpeta del día.
+func (o Options) BaseName(palletCodes []string, now time.Time) string {
+	tmpl := o.Template
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+
+	pallet := fmt.Sprintf("multiple_%d_pallets", len(palletCodes))
+	if len(palletCodes) == 1 {
+		pallet = palletCodes[0]
+	}
+	profile := o.Profile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	nombre := strings.NewReplacer(
+		"{pallet}", pallet,
+		"{date}", now.Format(dateLayout),
+		"{time}", now.Format(timeLayout),
+		"{profile}", profile,
+	).Replace(tmpl)
+
+	dir := o.Dir
+	if o.Daily {
+		dir = filepath.Join(dir, now.Format(dateLayout))
+	}
+	return filepath.Join(dir, nombre)
+}
+
+// Exists indica si ya hay algún archivo base+sufijo.
+func Exists(base string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if _, err := os.Stat(base + suffix); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSuffix devuelve el primer nombre base_2, base_3, ... que no tenga
+// archivos.
+func WithSuffix(base string, suffixes []string) string {
+	for n := 2; ; n++ {
+		candidato := base + "_" + strconv.Itoa(n)
+		if !Exists(candidato, suffixes) {
+			return candidato
+		}
+	}
+}
+
+// Files devuelve los archivos con alguna de las extensiones indicadas (por
+// ejemplo ".txt") del directorio de salida y de sus subcarpetas por día.
+func Files(dir string, extensiones []string) []string {
+	var archivos []string
+	for _, ext := range extensiones {
+		for _, patron := range []string{"*" + ext, filepath.Join("*", "*"+ext)} {
+			encontrados, err := filepath.Glob(filepath.Join(dir, patron))
+			if err == nil {
+				archivos = append(archivos, encontrados...)
+			}
+		}
+	}
+	return archivos
+}
@@ -0,0 +1,181 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+const (
+	reset       = "\033[0m"
+	clearScreen = "\033[H\033[2J"
+)
+
+var colorCodes = map[string]string{
+	"verde":    "\033[32m",
+	"green":    "\033[32m",
+	"rojo":     "\033[31m",
+	"red":      "\033[31m",
+	"amarillo": "\033[33m",
+	"yellow":   "\033[33m",
+	"azul":     "\033[34m",
+	"blue":     "\033[34m",
+}
+
+var (
+	colorEnabled = true
+	interactive  = true
+
+	// out recibe los mensajes para el usuario. En modo JSON se envían a
+	// stderr para que stdout contenga solo el resultado.
+	out io.Writer = os.Stdout
+
+	// quiet descarta los mensajes para el usuario; los errores siguen
+	// saliendo por stderr.
+	quiet bool
+)
+
+// Init detecta si la salida es una terminal y ajusta el modo de salida.
+// Sin TTY, con NO_COLOR, TERM=dumb o con noColor se desactivan los colores;
+// sin TTY o con plain tampoco se limpia la pantalla ni se hacen pausas.
+func Init(noColor, plain bool) {
+	stdoutTTY := isTerminal(os.Stdout)
+	stdinTTY := isTerminal(os.Stdin)
+
+	colorEnabled = stdoutTTY && !noColor && !plain &&
+		os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+	interactive = stdoutTTY && stdinTTY && !plain
+
+	if !colorEnabled {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetInteractive permite desactivar las pausas y preguntas, por ejemplo al
+// ejecutar un subcomando desde un script.
+func SetInteractive(enabled bool) {
+	interactive = enabled && interactive
+}
+
+// SetJSONMode envía los mensajes para el usuario a stderr, dejando stdout
+// libre para el resultado en JSON.
+func SetJSONMode() {
+	out = os.Stderr
+	interactive = false
+}
+
+// SetQuiet descarta o vuelve a mostrar los mensajes para el usuario, por
+// ejemplo mientras el modo watch reescribe los archivos.
+func SetQuiet(enabled bool) {
+	quiet = enabled
+}
+
+func writer() io.Writer {
+	if quiet {
+		return io.Discard
+	}
+	return out
+}
+
+func Print(a ...any) {
+	fmt.Fprint(writer(), a...)
+}
+
+func Println(a ...any) {
+	fmt.Fprintln(writer(), a...)
+}
+
+func Printf(format string, a ...any) {
+	fmt.Fprintf(writer(), format, a...)
+}
+
+// PrintJSON escribe v en stdout como JSON con sangría.
+func PrintJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func IsInteractive() bool {
+	return interactive
+}
+
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+func Color(text, color string) string {
+	if !colorEnabled {
+		return text
+	}
+
+	code, ok := colorCodes[strings.ToLower(color)]
+	if !ok {
+		code = reset
+	}
+	return code + text + reset
+}
+
+func ClearScreen() {
+	if interactive {
+		fmt.Fprint(writer(), clearScreen)
+	}
+}
+
+func Title(title string) {
+	fmt.Fprintln(writer(), "\n"+Color("["+title+"]", "verde"))
+}
+
+func Error(message string) {
+	fmt.Fprintln(os.Stderr, Color("\n"+i18n.T("output.error"), "verde")+" "+message)
+}
+
+func Success(message string) {
+	fmt.Fprintln(writer(), Color("\n"+i18n.T("output.success"), "verde")+" "+message)
+}
+
+func Warning(message string) {
+	fmt.Fprintln(writer(), Color("\n"+i18n.T("output.warning"), "verde")+" "+message)
+}
+
+// Pause espera a que el usuario presione Enter antes de volver al menú.
+func Pause() {
+	if !interactive {
+		return
+	}
+	fmt.Println("\n" + i18n.T("output.pause"))
+	fmt.Scanln()
+}
+
+// Confirm hace una pregunta de sí/no. Fuera del modo interactivo responde no.
+func Confirm(question string) bool {
+	if !interactive {
+		return false
+	}
+
+	fmt.Print(question + " " + i18n.T("output.confirm") + ": ")
+	respuesta, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	respuesta = strings.ToLower(strings.TrimSpace(respuesta))
+	for _, si := range strings.Split(i18n.T("output.confirm_yes"), ",") {
+		if respuesta == si {
+			return true
+		}
+	}
+	return false
+}
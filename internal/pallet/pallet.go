@@ -0,0 +1,202 @@
+// Package pallet interpreta y normaliza los códigos de pallet, con la forma
+// pl<AAAAMM><bodega><secuencia>, por ejemplo pl202505danl001.
+package pallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+)
+
+const (
+	prefix         = "pl"
+	sequenceDigits = 3
+	// Cantidad máxima de pallets que puede abarcar un rango.
+	maxRange = 100
+)
+
+// Code es un código de pallet separado en sus partes.
+type Code struct {
+	Year      int
+	Month     int
+	Warehouse string
+	Sequence  int
+}
+
+// String devuelve el código normalizado, en minúsculas y sin separadores.
+func (c Code) String() string {
+	return fmt.Sprintf("%s%04d%02d%s%0*d", prefix, c.Year, c.Month, c.Warehouse, sequenceDigits, c.Sequence)
+}
+
+// Error explica por qué un código de pallet no es válido.
+type Error struct {
+	Input  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%q: %s", e.Input, e.Reason)
+}
+
+// Normalize pasa el código a minúsculas y quita espacios, guiones, guiones
+// bajos, puntos y barras.
+func Normalize(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '_', '.', '/':
+			return -1
+		}
+		return r
+	}, strings.ToLower(value))
+}
+
+// Parse normaliza y valida un código de pallet.
+func Parse(value string) (Code, error) {
+	norm := Normalize(value)
+	fail := func(key string, args ...any) (Code, error) {
+		return Code{}, &Error{Input: strings.TrimSpace(value), Reason: i18n.T(key, args...)}
+	}
+
+	if norm == "" {
+		return fail("pallet.err_empty")
+	}
+	if !strings.HasPrefix(norm, prefix) {
+		return fail("pallet.err_prefix", prefix)
+	}
+	rest := norm[len(prefix):]
+
+	digits := leadingDigits(rest)
+	if len(digits) < 6 {
+		return fail("pallet.err_year_month", digits)
+	}
+	year, _ := strconv.Atoi(digits[:4])
+	month, _ := strconv.Atoi(digits[4:6])
+	if year < 2000 || month < 1 || month > 12 {
+		return fail("pallet.err_year_month", digits[:6])
+	}
+	rest = rest[6:]
+
+	warehouse := leadingLetters(rest)
+	if len(warehouse) < 2 {
+		return fail("pallet.err_warehouse", rest)
+	}
+	rest = rest[len(warehouse):]
+
+	seq := leadingDigits(rest)
+	if seq == "" || len(seq) > sequenceDigits {
+		return fail("pallet.err_sequence", rest, sequenceDigits)
+	}
+	if len(seq) < len(rest) {
+		return fail("pallet.err_trailing", rest[len(seq):])
+	}
+	sequence, _ := strconv.Atoi(seq)
+
+	return Code{Year: year, Month: month, Warehouse: warehouse, Sequence: sequence}, nil
+}
+
+// ParseList interpreta una lista de códigos separados por comas, con rangos
+// como pl202505danl001..010 o pl202505danl001..pl202505danl010. Devuelve los
+// códigos normalizados sin repetir, en el orden ingresado, o un error que
+// explica cada código inválido.
+func ParseList(value string) ([]string, error) {
+	var codes []string
+	var errs []error
+	vistos := map[string]bool{}
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		expanded, err := parseEntry(part)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, code := range expanded {
+			if !vistos[code] {
+				vistos[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	return codes, errors.Join(errs...)
+}
+
+func parseEntry(entry string) ([]string, error) {
+	desde, hasta, esRango := strings.Cut(entry, "..")
+	inicio, err := Parse(desde)
+	if err != nil {
+		return nil, err
+	}
+	if !esRango {
+		return []string{inicio.String()}, nil
+	}
+
+	fin, err := rangeEnd(inicio, hasta)
+	if err != nil {
+		return nil, &Error{Input: entry, Reason: err.Error()}
+	}
+	if fin.Sequence < inicio.Sequence {
+		return nil, &Error{Input: entry, Reason: i18n.T("pallet.err_range_order")}
+	}
+	if fin.Sequence-inicio.Sequence+1 > maxRange {
+		return nil, &Error{Input: entry, Reason: i18n.T("pallet.err_range_size", maxRange)}
+	}
+
+	var codes []string
+	for seq := inicio.Sequence; seq <= fin.Sequence; seq++ {
+		code := inicio
+		code.Sequence = seq
+		codes = append(codes, code.String())
+	}
+	return codes, nil
+}
+
+// rangeEnd interpreta el final de un rango, que puede ser solo la secuencia o
+// un código completo del mismo mes y bodega que el inicio.
+func rangeEnd(inicio Code, value string) (Code, error) {
+	norm := Normalize(value)
+	if norm != "" && leadingDigits(norm) == norm {
+		if len(norm) > sequenceDigits {
+			return Code{}, errors.New(i18n.T("pallet.err_sequence", norm, sequenceDigits))
+		}
+		fin := inicio
+		fin.Sequence, _ = strconv.Atoi(norm)
+		return fin, nil
+	}
+
+	fin, err := Parse(value)
+	if err != nil {
+		var perr *Error
+		if errors.As(err, &perr) {
+			return Code{}, errors.New(perr.Reason)
+		}
+		return Code{}, err
+	}
+	if fin.Year != inicio.Year || fin.Month != inicio.Month || fin.Warehouse != inicio.Warehouse {
+		return Code{}, errors.New(i18n.T("pallet.err_range_mismatch"))
+	}
+	return fin, nil
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+func leadingLetters(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= 'a' && s[i] <= 'z' {
+		i++
+	}
+	return s[:i]
+}
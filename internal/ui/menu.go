@@ -2,18 +2,22 @@ package ui
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Cait-dev/alas-tools-cli/internal/config"
 	"github.com/Cait-dev/alas-tools-cli/internal/handlers"
+	"github.com/Cait-dev/alas-tools-cli/internal/i18n"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
 )
 
 type menuItem struct {
 	title, desc string
+	quit        bool
 }
 
 func (m menuItem) Title() string       { return m.title }
@@ -28,12 +32,12 @@ type model struct {
 
 func initialModel() model {
 	items := []list.Item{
-		menuItem{title: "Opción 1: Corregir X&Y", desc: "Herramienta para corregir coordenadas usando Google Places"},
-		menuItem{title: "Opción 2: Mostrar una ruta optimizada de un pallet", desc: "Compara las rutas"},
-		menuItem{title: "Opción 3: Obtener coordenadas", desc: "Extrae coordenadas de un pallet y las guarda en un archivo"},
-		menuItem{title: "Opción 4: Generar mapa HTML", desc: "Crea un mapa interactivo a partir de un archivo de coordenadas"},
-		menuItem{title: "Opción 5: Ayuda", desc: "Muestra la información de ayuda"},
-		menuItem{title: "Salir", desc: "Salir de la aplicación"},
+		menuItem{title: i18n.T("menu.xy.title"), desc: i18n.T("menu.xy.desc")},
+		menuItem{title: i18n.T("menu.route.title"), desc: i18n.T("menu.route.desc")},
+		menuItem{title: i18n.T("menu.coords.title"), desc: i18n.T("menu.coords.desc")},
+		menuItem{title: i18n.T("menu.map.title"), desc: i18n.T("menu.map.desc")},
+		menuItem{title: i18n.T("menu.help.title"), desc: i18n.T("menu.help.desc")},
+		menuItem{title: i18n.T("menu.quit.title"), desc: i18n.T("menu.quit.desc"), quit: true},
 	}
 
 	delegate := list.NewDefaultDelegate()
@@ -41,7 +45,7 @@ func initialModel() model {
 	delegate.Styles.NormalTitle = itemStyle
 
 	l := list.New(items, delegate, 80, 20)
-	l.Title = "Menú Principal"
+	l.Title = i18n.T("menu.title")
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(false)
 	l.Styles.Title = titleStyle
@@ -74,7 +78,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			i, ok := m.list.SelectedItem().(menuItem)
 			if ok {
-				if strings.Contains(i.title, "Salir") {
+				if i.quit {
 					m.quitting = true
 					return m, tea.Quit
 				}
@@ -92,14 +96,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) View() string {
 	if m.quitting {
-		return quitTextStyle.Render("¡Gracias por usar Alas-Tools-Cli!")
+		return quitTextStyle.Render(i18n.T("menu.thanks"))
 	}
 
 	return docStyle.Render(m.list.View())
 }
 
 func ShowStartScreen() {
-	fmt.Print("\033[H\033[2J")
+	output.ClearScreen()
 	asciiArt := `
 /$$$$$$  /$$                         /$$$$$$$$                  /$$              /$$$$$$  /$$ /$$
 /$$__  $$| $$                        |__  $$__/                 | $$             /$$__  $$| $$|__/
@@ -116,13 +120,11 @@ func ShowStartScreen() {
 │ Productivity - & much more!     │
 └─────────────────────────────────┘
 `
-	verde := "\033[32m"
-	reset := "\033[0m"
-	fmt.Println(verde + asciiArt + subtitulo + reset)
+	fmt.Println(output.Color(asciiArt+subtitulo, "verde"))
 
-	fmt.Println("\nBienvenido a Alas-Tools-Cli v1.1.1")
+	fmt.Println("\n" + i18n.T("splash.welcome"))
 	fmt.Println("─────────────────────────────")
-	fmt.Println("Use the arrow keys to navigate: ↑ ↓")
+	fmt.Println(i18n.T("splash.instructions"))
 	time.Sleep(2 * time.Second)
 }
 
@@ -133,7 +135,7 @@ func StartMainMenu() {
 		p := tea.NewProgram(m)
 		finalModel, err := p.Run()
 		if err != nil {
-			fmt.Printf("Error al iniciar la aplicación: %v\n", err)
+			fmt.Println(i18n.T("menu.start_error", err))
 			os.Exit(1)
 		}
 
@@ -141,21 +143,31 @@ func StartMainMenu() {
 			if finalModel.quitting {
 				salir = true
 			} else {
+				var err error
 				switch finalModel.action {
 				case 0:
-					handlers.CorregirXY()
+					err = handlers.CorregirXY()
 				case 1:
-					handlers.MostrarRutaOptimizada()
+					err = handlers.MostrarRutaOptimizada()
 				case 2:
-					handlers.ObtenerCoordenadas()
+					var opts handlers.CoordsOptions
+					if opts, err = handlers.DefaultCoordsOptions(); err == nil {
+						err = handlers.ObtenerCoordenadas("", opts)
+					}
 				case 3:
-					handlers.GenerarMapaHTML("")
+					err = handlers.GenerarMapaHTML("", handlers.MapOptions{Offline: config.MapOffline()})
 				case 4:
-					handlers.MostrarAyuda()
+					err = handlers.MostrarAyuda()
 				}
 
-				fmt.Print("\033[H\033[2J")
-				fmt.Println("\nVolviendo al menú principal...")
+				if err != nil {
+					slog.Error("la opción del menú terminó con error", "action", finalModel.action, "error", err)
+					output.Error(err.Error())
+				}
+				output.Pause()
+
+				output.ClearScreen()
+				fmt.Println("\n" + i18n.T("menu.returning"))
 				time.Sleep(1 * time.Second)
 			}
 		}
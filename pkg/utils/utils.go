@@ -1,28 +1,11 @@
 package utils
 
 import (
-	"fmt"
-	"strings"
+	"github.com/Cait-dev/alas-tools-cli/internal/output"
 )
 
 func ColorText(text string, color string) string {
-	var colorCode string
-
-	switch strings.ToLower(color) {
-	case "verde", "green":
-		colorCode = "\033[32m"
-	case "rojo", "red":
-		colorCode = "\033[31m"
-	case "amarillo", "yellow":
-		colorCode = "\033[33m"
-	case "azul", "blue":
-		colorCode = "\033[34m"
-	default:
-		colorCode = "\033[0m"
-	}
-
-	reset := "\033[0m"
-	return colorCode + text + reset
+	return output.Color(text, color)
 }
 
 func FormatTitle(title string) string {
@@ -30,13 +13,13 @@ func FormatTitle(title string) string {
 }
 
 func PrintError(message string) {
-	fmt.Println(ColorText("\n[ERROR]", "verde") + " " + message)
+	output.Error(message)
 }
 
 func PrintSuccess(message string) {
-	fmt.Println(ColorText("\n[ÉXITO]", "verde") + " " + message)
+	output.Success(message)
 }
 
 func PrintWarning(message string) {
-	fmt.Println(ColorText("\n[AVISO]", "verde") + " " + message)
+	output.Warning(message)
 }